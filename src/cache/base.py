@@ -35,7 +35,7 @@ class CacheProvider(ABC):
         try:
             return decompress(data).decode("utf-8")
         except Exception as e:
-            logger.warning("Failed to decompress/decode cached string: %s", e)
+            logger.warning("Failed to decompress/decode cached string", extra={"error": str(e)})
             return None
 
     def _encode_dict(self, data: dict[str, Any]) -> bytes:
@@ -46,7 +46,7 @@ class CacheProvider(ABC):
             res: dict[str, Any] = json.loads(decompress(data).decode("utf-8"))
             return res
         except Exception as e:
-            logger.warning("Failed to decompress/decode cached dict: %s", e)
+            logger.warning("Failed to decompress/decode cached dict", extra={"error": str(e)})
             return None
 
     @abstractmethod
@@ -82,3 +82,17 @@ class CacheProvider(ABC):
     async def put_dict(self, key: str, data: dict[str, Any], ttl_seconds: int) -> None:
         """Caches a dict."""
         pass
+
+    @abstractmethod
+    async def put_if_absent(self, key: str, ttl_seconds: int) -> bool:
+        """
+        Atomically records a marker key unless it is already present.
+
+        Args:
+            key: The marker key.
+            ttl_seconds: How long the marker is kept.
+
+        Returns:
+            True if the key was recorded, False if it was already present.
+        """
+        pass
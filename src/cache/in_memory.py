@@ -4,10 +4,14 @@ Local in-memory cache provider implementation.
 
 import asyncio
 import time
+from collections.abc import Callable
 from typing import Any
 
 from .base import CacheProvider
 
+# How often expired put_if_absent markers are removed; one is recorded per processed message and update.
+MARKER_SWEEP_INTERVAL_SECONDS = 60
+
 
 class InMemoryCacheProvider(CacheProvider):
     """
@@ -15,19 +19,29 @@ class InMemoryCacheProvider(CacheProvider):
     Used when Valkey is not configured or unavailable.
     """
 
-    def __init__(self, compression_method: str = "gzip") -> None:
+    def __init__(self, compression_method: str = "gzip", clock: Callable[[], float] = time.monotonic) -> None:
+        """
+        Initializes the InMemoryCacheProvider.
+
+        Args:
+            compression_method: Compression applied to cached values.
+            clock: Monotonic time source in seconds, used for rate limit windows and TTLs.
+        """
         super().__init__(compression_method)
+        self._clock = clock
         self._rate_limits: dict[int, float] = {}
         self._rate_limit_lock = asyncio.Lock()
 
         # Caches
         self._cache: dict[str, tuple[bytes, float]] = {}
         self._cache_dict: dict[str, tuple[bytes, float]] = {}
+        self._markers: dict[str, float] = {}
+        self._next_marker_sweep = self._clock() + MARKER_SWEEP_INTERVAL_SECONDS
         self._cache_lock = asyncio.Lock()
 
     async def is_rate_limited(self, user_id: int, window_seconds: int) -> bool:
         async with self._rate_limit_lock:
-            now = time.monotonic()
+            now = self._clock()
             last_request = self._rate_limits.get(user_id)
             if last_request is not None and (now - last_request) < window_seconds:
                 return True
@@ -44,7 +58,7 @@ class InMemoryCacheProvider(CacheProvider):
                 return None
 
             compressed, expires_at = cached
-            if time.monotonic() > expires_at:
+            if self._clock() > expires_at:
                 self._cache.pop(cache_key, None)
                 return None
 
@@ -54,7 +68,7 @@ class InMemoryCacheProvider(CacheProvider):
         async with self._cache_lock:
             cache_key = f"{key}:{self._compression_method.value}"
             compressed = self._encode_text(text)
-            self._cache[cache_key] = (compressed, time.monotonic() + ttl_seconds)
+            self._cache[cache_key] = (compressed, self._clock() + ttl_seconds)
 
     async def get_dict(self, key: str) -> dict[str, Any] | None:
         async with self._cache_lock:
@@ -64,7 +78,7 @@ class InMemoryCacheProvider(CacheProvider):
                 return None
 
             compressed, expires_at = cached
-            if time.monotonic() > expires_at:
+            if self._clock() > expires_at:
                 self._cache_dict.pop(cache_key, None)
                 return None
 
@@ -74,4 +88,18 @@ class InMemoryCacheProvider(CacheProvider):
         async with self._cache_lock:
             cache_key = f"{key}:{self._compression_method.value}"
             compressed = self._encode_dict(data)
-            self._cache_dict[cache_key] = (compressed, time.monotonic() + ttl_seconds)
+            self._cache_dict[cache_key] = (compressed, self._clock() + ttl_seconds)
+
+    async def put_if_absent(self, key: str, ttl_seconds: int) -> bool:
+        async with self._cache_lock:
+            now = self._clock()
+            if now >= self._next_marker_sweep:
+                self._markers = {marker: expires_at for marker, expires_at in self._markers.items() if now <= expires_at}
+                self._next_marker_sweep = now + MARKER_SWEEP_INTERVAL_SECONDS
+
+            expires_at = self._markers.get(key)
+            if expires_at is not None and now <= expires_at:
+                return False
+
+            self._markers[key] = now + ttl_seconds
+            return True
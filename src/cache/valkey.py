@@ -46,10 +46,10 @@ class ValkeyProvider(CacheProvider):
             logger.warning("Valkey timeout")
             return None
         except (ValkeyConnectionError, ConnectionError, OSError) as e:
-            logger.warning(f"Valkey connection error: {e}")
+            logger.warning("Valkey connection error", extra={"error": str(e)})
             return None
         except Exception as e:
-            logger.warning(f"Valkey operation failed: {e}")
+            logger.warning("Valkey operation failed", extra={"error": str(e)})
             return None
 
     async def is_rate_limited(self, user_id: int, window_seconds: int) -> bool:
@@ -113,3 +113,13 @@ class ValkeyProvider(CacheProvider):
             await client.setex(cache_key, ttl_seconds, compressed)
 
         await self._safe_execute(_valkey_set)
+
+    async def put_if_absent(self, key: str, ttl_seconds: int) -> bool:
+        """Atomic marker using SET NX EX. Fails open, so an unavailable Valkey never drops updates."""
+
+        async def _valkey_set_nx() -> bool:
+            client = await self._get_client()
+            return bool(await client.set(key, b"1", ex=ttl_seconds, nx=True))
+
+        res: bool | None = await self._safe_execute(_valkey_set_nx)
+        return res is not False
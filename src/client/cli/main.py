@@ -0,0 +1,137 @@
+"""
+Command-line entry point.
+
+Runs the same load -> clean -> summarize pipeline as the Telegram bot and prints
+the result, for scripting and debugging without Telegram. URLs are taken from
+``--url`` or, if none are given, from standard input:
+
+    python -m src.client.cli.main --url https://youtu.be/dQw4w9WgXcQ --lang de --style bullets
+    cat links.txt | python -m src.client.cli.main --json
+"""
+
+from __future__ import annotations
+
+import argparse
+import asyncio
+import dataclasses
+import json
+import logging
+import sys
+from collections.abc import Sequence
+from typing import TextIO
+
+from src.config import Settings
+from src.load.url_extraction import extract_canonical_urls
+from src.load.video_loader_factory import ProviderVideoLoader
+from src.localization import supported_locales
+from src.logger import configure_logging
+from src.transform.chapter_summary import summarize_transcript
+from src.transform.summarization import OpenAISummarizer
+from src.transform.summary_style import SummaryStyle
+
+logger = logging.getLogger(__name__)
+
+
+def parse_args(argv: Sequence[str] | None = None) -> argparse.Namespace:
+    """
+    Parse command-line arguments.
+
+    Args:
+        argv: Arguments without the program name; defaults to ``sys.argv[1:]``.
+
+    Returns:
+        Parsed arguments.
+    """
+    parser = argparse.ArgumentParser(description="Summarize video URLs with the configured LLM.")
+    parser.add_argument("--url", action="append", default=[], help="Video URL to summarize; repeatable. Reads stdin if omitted.")
+    parser.add_argument("--lang", default="en", choices=supported_locales(), help="Summary language (default: en).")
+    parser.add_argument(
+        "--style", choices=[style.value for style in SummaryStyle], help="Summary style (default: SUMMARY_STYLE_<LANG> or SUMMARY_STYLE)."
+    )
+    parser.add_argument("--model", help="Override OPENAI_MODEL for this run.")
+    parser.add_argument("--by-chapters", action="store_true", help="Summarize videos with chapters chapter by chapter.")
+    parser.add_argument("--json", action="store_true", help="Print one JSON object per URL instead of Markdown.")
+    return parser.parse_args(argv)
+
+
+def collect_urls(args: argparse.Namespace, stdin: TextIO) -> list[str]:
+    """
+    Return the URLs to summarize: ``--url`` values, or every supported video URL found in stdin.
+
+    Args:
+        args: Parsed arguments.
+        stdin: Stream to read URLs from when no ``--url`` is given.
+
+    Returns:
+        URLs in the order given.
+    """
+    if args.url:
+        return list(args.url)
+    return extract_canonical_urls(stdin.read())
+
+
+async def summarize_urls(
+    args: argparse.Namespace,
+    urls: Sequence[str],
+    loader: ProviderVideoLoader,
+    summarizer: OpenAISummarizer,
+    out: TextIO,
+) -> int:
+    """
+    Summarize each URL and print the results; a failed URL does not stop the others.
+
+    Args:
+        args: Parsed arguments with language, style, chapter mode and output format.
+        urls: Video URLs to summarize.
+        loader: Transcript loader.
+        summarizer: LLM summarizer.
+        out: Stream to print summaries to.
+
+    Returns:
+        Process exit code: 0 if every URL was summarized, 1 otherwise.
+    """
+    exit_code = 0
+    style = SummaryStyle(args.style) if args.style else summarizer.default_style(args.lang)
+    for url in urls:
+        try:
+            transcript = await loader.load(url)
+            summary = await summarize_transcript(summarizer, transcript, args.lang, style, args.by_chapters)
+        except Exception as exc:
+            logger.exception("Failed to summarize URL", extra={"url": url, "error": str(exc)})
+            exit_code = 1
+            continue
+
+        if args.json:
+            record = {"url": url, "title": transcript.title, "language": args.lang, "style": style.value, "summary": summary}
+            print(json.dumps(record, ensure_ascii=False), file=out)
+        else:
+            print(f"# {transcript.title}\n\n{url}\n\n{summary.strip()}\n", file=out)
+    return exit_code
+
+
+async def main(argv: Sequence[str] | None = None) -> int:
+    """
+    Load settings, build the pipeline and summarize the requested URLs.
+
+    Args:
+        argv: Arguments without the program name; defaults to ``sys.argv[1:]``.
+
+    Returns:
+        Process exit code.
+    """
+    args = parse_args(argv)
+    urls = collect_urls(args, sys.stdin)
+    if not urls:
+        logger.error("No supported video URLs given")
+        return 2
+
+    settings = Settings.from_env(require_telegram=False)
+    if args.model:
+        settings = dataclasses.replace(settings, openai_model=args.model)
+
+    return await summarize_urls(args, urls, ProviderVideoLoader(settings), OpenAISummarizer(settings), sys.stdout)
+
+
+if __name__ == "__main__":
+    configure_logging()
+    sys.exit(asyncio.run(main()))
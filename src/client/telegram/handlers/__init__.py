@@ -1,6 +1,19 @@
+from src.client.telegram.handlers.callbacks import callback_router as callbacks_router
 from src.client.telegram.handlers.commands import start_router as commands_router
 from src.client.telegram.handlers.errors import error_router as errors_router
-from src.client.telegram.handlers.helpers import get_language
+from src.client.telegram.handlers.language_resolution import get_language
 from src.client.telegram.handlers.messages import message_router as messages_router
+from src.client.telegram.handlers.refresh import refresh_router
+from src.client.telegram.handlers.style_keyboard import SummarySourceStore
+from src.client.telegram.handlers.transcript import transcript_router
 
-__all__ = ["commands_router", "messages_router", "errors_router", "get_language"]
+__all__ = [
+    "callbacks_router",
+    "commands_router",
+    "messages_router",
+    "errors_router",
+    "refresh_router",
+    "get_language",
+    "SummarySourceStore",
+    "transcript_router",
+]
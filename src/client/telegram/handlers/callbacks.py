@@ -0,0 +1,149 @@
+import logging
+
+from aiogram import Router
+from aiogram.types import CallbackQuery, Message
+
+from src.client.telegram.handlers.language_resolution import get_preferred_language
+from src.client.telegram.handlers.load_error_replies import load_error_key
+from src.client.telegram.handlers.messages import summarize_video_url
+from src.client.telegram.handlers.style_keyboard import SummarySourceStore, SummaryStyleCallback, build_style_keyboard
+from src.client.telegram.handlers.summary_reply import reply_with_summary
+from src.client.telegram.handlers.video_picker import PickVideoCallback
+from src.config import Settings
+from src.language_preferences import UserLanguagePreferences
+from src.load.video_loader_factory import ProviderVideoLoader
+from src.load.video_provider import find_video_id
+from src.localization import translate
+from src.log_context import add_log_context
+from src.rate_limiter import UserRateLimiter
+from src.transform.chapter_summary import summarize_transcript
+from src.transform.summarization import OpenAISummarizer, SummarizationTimeoutError, SummaryUnavailableError
+
+logger = logging.getLogger(__name__)
+
+callback_router = Router()
+
+
+@callback_router.callback_query(SummaryStyleCallback.filter())
+async def handle_style_callback(  # noqa: PLR0913
+    query: CallbackQuery,
+    callback_data: SummaryStyleCallback,
+    loader: ProviderVideoLoader,
+    summarizer: OpenAISummarizer,
+    rate_limiter: UserRateLimiter,
+    settings: Settings,
+    source_store: SummarySourceStore,
+    language_preferences: UserLanguagePreferences,
+) -> None:
+    """Re-summarizes a previously summarized video in the style chosen with an inline button."""
+
+    user = query.from_user
+    language = await get_preferred_language(user, language_preferences, query.message.chat if query.message else None)
+    message = query.message
+    if not isinstance(message, Message):
+        await query.answer()
+        return
+
+    if await rate_limiter.is_limited(user.id):
+        logger.warning("Rate Limit exceeded", extra={"userID": user.id, "username": user.username, "language": language})
+        await query.answer(
+            translate("telegram.error.rate_limited", locale=language, rateLimitWindow=settings.rate_limit_window_seconds),
+            show_alert=True,
+        )
+        return
+
+    video_url = await source_store.resolve(callback_data.token)
+    if video_url is None:
+        logger.info("Summary source expired", extra={"userID": user.id, "token": callback_data.token})
+        await query.answer(translate("telegram.error.style_expired", locale=language), show_alert=True)
+        return
+
+    video_id = find_video_id(video_url) or video_url
+    add_log_context(videoID=video_id, correlation_id=f"{user.id}:{video_id}")
+    await query.answer()
+    logger.info(
+        "Re-summarizing video",
+        extra={"userID": user.id, "username": user.username, "url": video_url, "style": callback_data.style.value},
+    )
+    processing_message = await message.reply(translate("telegram.progress.summarizing", locale=language))
+
+    try:
+        transcript = await loader.load(video_url)
+    except Exception as exc:
+        logger.exception("Failed to load transcript", extra={"userID": user.id, "username": user.username, "url": video_url, "error": str(exc)})
+        await processing_message.edit_text(translate(load_error_key(exc), locale=language))
+        return
+
+    try:
+        summary = await summarize_transcript(summarizer, transcript, language, callback_data.style, settings.feature_flags.chapter_summaries)
+    except SummarizationTimeoutError as exc:
+        logger.warning("Re-summarization timed out", extra={"userID": user.id, "url": video_url, "error": str(exc)})
+        await processing_message.edit_text(translate("telegram.error.summary_timeout", locale=language))
+        return
+    except SummaryUnavailableError as exc:
+        logger.warning("No usable summary from the model", extra={"userID": user.id, "url": video_url, "error": str(exc)})
+        await processing_message.edit_text(translate("telegram.error.summary_unavailable", locale=language))
+        return
+    except Exception as exc:
+        logger.exception(
+            "Failed to re-summarize video",
+            extra={"userID": user.id, "username": user.username, "url": video_url, "error": str(exc)},
+        )
+        await processing_message.edit_text(translate("telegram.error.summary_failed", locale=language))
+        return
+
+    await reply_with_summary(
+        message,
+        video_url,
+        transcript,
+        summary,
+        language,
+        settings,
+        reply_markup=build_style_keyboard(callback_data.token, language) if settings.feature_flags.style_keyboard else None,
+    )
+
+    try:
+        await processing_message.delete()
+    except Exception as exc:
+        logger.exception(
+            "Failed to delete processing message",
+            extra={"userID": user.id, "username": user.username, "error": str(exc)},
+        )
+
+
+@callback_router.callback_query(PickVideoCallback.filter())
+async def handle_pick_callback(  # noqa: PLR0913
+    query: CallbackQuery,
+    callback_data: PickVideoCallback,
+    loader: ProviderVideoLoader,
+    summarizer: OpenAISummarizer,
+    settings: Settings,
+    source_store: SummarySourceStore,
+    language_preferences: UserLanguagePreferences,
+) -> None:
+    """
+    Summarizes the video picked from the keyboard offered for a message with several links.
+
+    The message was already counted by the rate limiter, so picking is not limited again.
+    The keyboard message becomes the progress message and the summary replies to the user's message.
+    """
+
+    user = query.from_user
+    language = await get_preferred_language(user, language_preferences, query.message.chat if query.message else None)
+    picker_message = query.message
+    if not isinstance(picker_message, Message):
+        await query.answer()
+        return
+
+    video_url = await source_store.resolve(callback_data.token)
+    if video_url is None:
+        logger.info("Picked video expired", extra={"userID": user.id, "token": callback_data.token})
+        await query.answer(translate("telegram.error.choice_expired", locale=language), show_alert=True)
+        return
+
+    await query.answer()
+    logger.info("Video picked", extra={"userID": user.id, "username": user.username, "url": video_url})
+    # Editing without reply_markup also removes the keyboard, so the video is not picked twice.
+    await picker_message.edit_text(translate("telegram.progress.processing", locale=language))
+    source_message = picker_message.reply_to_message or picker_message
+    await summarize_video_url(source_message, picker_message, video_url, language, loader, summarizer, settings, source_store)
@@ -1,16 +1,70 @@
+import html
 import logging
 
-from aiogram import Router
-from aiogram.filters import Command
+from aiogram import Bot, Router
+from aiogram.enums import ChatMemberStatus, ChatType
+from aiogram.filters import Command, CommandObject, CommandStart
 from aiogram.types import Message
 
-from src.client.telegram.handlers.helpers import get_language
-from src.localization import translate
+from src.client.telegram.handlers.deep_link import parse_start_payload
+from src.client.telegram.handlers.language_resolution import get_language, get_preferred_language
+from src.client.telegram.handlers.messages import summarize_video_url
+from src.client.telegram.handlers.style_keyboard import SummarySourceStore
+from src.config import Settings
+from src.language_preferences import UserLanguagePreferences
+from src.load.video_loader_factory import ProviderVideoLoader
+from src.localization import normalize_locale, supported_locales, translate
+from src.rate_limiter import UserRateLimiter
+from src.transform.model_selection import ModelSelection
+from src.transform.summarization import OpenAISummarizer
 
 logger = logging.getLogger(__name__)
 
 start_router = Router()
 
+# /setlang arguments that clear the group's language, so members get summaries in their own languages again.
+CLEAR_CHAT_LANGUAGE_ARGS = frozenset({"off", "reset", "none"})
+CHAT_ADMIN_STATUSES = frozenset({ChatMemberStatus.CREATOR, ChatMemberStatus.ADMINISTRATOR})
+
+
+@start_router.message(CommandStart(deep_link=True))
+async def start_deep_link_command(  # noqa: PLR0913
+    message: Message,
+    command: CommandObject,
+    loader: ProviderVideoLoader,
+    summarizer: OpenAISummarizer,
+    rate_limiter: UserRateLimiter,
+    settings: Settings,
+    source_store: SummarySourceStore,
+    language_preferences: UserLanguagePreferences,
+) -> None:
+    """Handles /start with a deep-link payload: records the referral and summarizes an encoded video URL right away."""
+    user = message.from_user
+    language = await get_preferred_language(user, language_preferences, message.chat)
+    payload = parse_start_payload(command.args)
+    logger.info(
+        "User started bot",
+        extra={
+            "userID": user.id if user else None,
+            "username": user.username if user else None,
+            "language": language,
+            "referral": payload.referral,
+            "url": payload.url,
+        },
+    )
+    await message.reply(translate("telegram.welcome.message", locale=language))
+
+    if user is None or payload.url is None:
+        return
+
+    if await rate_limiter.is_limited(user.id):
+        logger.warning("Rate Limit exceeded", extra={"userID": user.id, "username": user.username, "language": language})
+        await message.reply(translate("telegram.error.rate_limited", locale=language, rateLimitWindow=settings.rate_limit_window_seconds))
+        return
+
+    processing_message = await message.reply(translate("telegram.progress.processing", locale=language))
+    await summarize_video_url(message, processing_message, payload.url, language, loader, summarizer, settings, source_store)
+
 
 @start_router.message(Command("start"))
 async def start_command(message: Message) -> None:
@@ -26,3 +80,97 @@ async def start_command(message: Message) -> None:
         },
     )
     await message.reply(translate("telegram.welcome.message", locale=language))
+
+
+@start_router.message(Command("language"))
+async def language_command(message: Message, command: CommandObject, language_preferences: UserLanguagePreferences) -> None:
+    """Handles the /language command, setting the language summaries are written in."""
+    user = message.from_user
+    language = await get_preferred_language(user, language_preferences)
+    locales = ", ".join(supported_locales())
+
+    if user is None or not command.args:
+        await message.reply(translate("telegram.language.usage", locale=language, language=language, locales=locales))
+        return
+
+    requested = normalize_locale(command.args)
+    if requested not in supported_locales():
+        await message.reply(translate("telegram.language.invalid", locale=language, code=html.escape(command.args.strip()), locales=locales))
+        return
+
+    await language_preferences.set(user.id, requested)
+    logger.info(
+        "User changed language",
+        extra={
+            "userID": user.id,
+            "username": user.username,
+            "language": requested,
+        },
+    )
+    await message.reply(translate("telegram.language.updated", locale=requested, language=requested))
+
+
+@start_router.message(Command("setlang"))
+async def set_chat_language_command(message: Message, command: CommandObject, bot: Bot, language_preferences: UserLanguagePreferences) -> None:
+    """Handles /setlang in groups, letting admins set the language of all summaries in the chat."""
+    user = message.from_user
+    chat = message.chat
+    language = await get_preferred_language(user, language_preferences, chat)
+    if chat.type == ChatType.PRIVATE:
+        await message.reply(translate("telegram.chat_language.group_only", locale=language))
+        return
+
+    locales = ", ".join(supported_locales())
+    if user is None or not command.args:
+        await message.reply(translate("telegram.chat_language.usage", locale=language, locales=locales))
+        return
+
+    member = await bot.get_chat_member(chat.id, user.id)
+    if member.status not in CHAT_ADMIN_STATUSES:
+        await message.reply(translate("telegram.chat_language.admins_only", locale=language))
+        return
+
+    if command.args.strip().lower() in CLEAR_CHAT_LANGUAGE_ARGS:
+        await language_preferences.set_chat(chat.id, None)
+        logger.info("Chat language cleared", extra={"chat_id": chat.id, "userID": user.id})
+        await message.reply(translate("telegram.chat_language.cleared", locale=await get_preferred_language(user, language_preferences)))
+        return
+
+    requested = normalize_locale(command.args)
+    if requested not in supported_locales():
+        await message.reply(translate("telegram.language.invalid", locale=language, code=html.escape(command.args.strip()), locales=locales))
+        return
+
+    await language_preferences.set_chat(chat.id, requested)
+    logger.info("Chat language changed", extra={"chat_id": chat.id, "userID": user.id, "language": requested})
+    await message.reply(translate("telegram.chat_language.updated", locale=requested, language=requested))
+
+
+@start_router.message(Command("model"))
+async def model_command(
+    message: Message,
+    command: CommandObject,
+    settings: Settings,
+    model_selection: ModelSelection,
+    language_preferences: UserLanguagePreferences,
+) -> None:
+    """Handles /model for the admins in ``TELEGRAM_ADMIN_IDS``: shows the active summarization model, or switches to another allowed one."""
+    user = message.from_user
+    language = await get_preferred_language(user, language_preferences, message.chat)
+    if user is None or user.id not in settings.telegram_admin_ids:
+        await message.reply(translate("telegram.model.admins_only", locale=language))
+        return
+
+    models = ", ".join(model_selection.allowed_models())
+    if not command.args:
+        await message.reply(translate("telegram.model.current", locale=language, model=await model_selection.get(), models=models))
+        return
+
+    requested = command.args.strip()
+    if requested not in model_selection.allowed_models():
+        await message.reply(translate("telegram.model.invalid", locale=language, model=html.escape(requested), models=models))
+        return
+
+    await model_selection.set(requested)
+    logger.info("Summarization model changed", extra={"userID": user.id, "username": user.username, "model": requested})
+    await message.reply(translate("telegram.model.updated", locale=language, model=requested))
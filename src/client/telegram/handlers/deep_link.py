@@ -0,0 +1,50 @@
+import base64
+import binascii
+from dataclasses import dataclass
+
+from src.load.url_extraction import extract_canonical_urls
+
+REFERRAL_PREFIX = "ref_"
+
+
+@dataclass(frozen=True)
+class StartPayload:
+    """
+    Parsed payload of a ``t.me/<bot>?start=<payload>`` deep link.
+
+    Telegram only allows ``[A-Za-z0-9_-]`` in payloads, so a video URL is passed
+    URL-safe base64 encoded, and a referral as ``ref_<code>``.
+    """
+
+    referral: str | None = None
+    url: str | None = None
+
+
+def encode_url_payload(url: str) -> str:
+    """Encodes a video URL as a deep-link payload."""
+    return base64.urlsafe_b64encode(url.encode()).decode().rstrip("=")
+
+
+def parse_start_payload(payload: str | None) -> StartPayload:
+    """
+    Parses a /start payload into a referral code or a supported video URL.
+
+    Args:
+        payload: Text after ``/start``, if any.
+
+    Returns:
+        Parsed payload; empty if the payload is neither a referral nor an encoded video URL.
+    """
+    payload = (payload or "").strip()
+    if not payload:
+        return StartPayload()
+    if payload.startswith(REFERRAL_PREFIX):
+        return StartPayload(referral=payload.removeprefix(REFERRAL_PREFIX) or None)
+
+    try:
+        decoded = base64.urlsafe_b64decode(payload + "=" * (-len(payload) % 4)).decode()
+    except (binascii.Error, UnicodeDecodeError, ValueError):
+        return StartPayload()
+
+    urls = extract_canonical_urls(decoded)
+    return StartPayload(url=urls[0]) if len(urls) == 1 else StartPayload()
@@ -1,9 +1,9 @@
 import logging
 
 from aiogram import Router
-from aiogram.types import ErrorEvent
+from aiogram.types import ErrorEvent, Message, User
 
-from src.client.telegram.handlers.helpers import get_language
+from src.client.telegram.handlers.language_resolution import get_language
 from src.localization import translate
 
 logger = logging.getLogger(__name__)
@@ -13,19 +13,42 @@ error_router = Router()
 
 @error_router.errors()
 async def error_handler(event: ErrorEvent) -> None:
-    """Handles errors during Telegram update processing, logging the exception and notifying the user."""
+    """
+    Handles errors during Telegram update processing, logging the exception and notifying the user.
 
-    logger.exception(
+    For a failed button press the callback query is answered, so the button stops loading,
+    and the error is sent in reply to the message with the button.
+    """
+    update = event.update
+    callback_query = update.callback_query if update.message is None else None
+    if callback_query:
+        user: User | None = callback_query.from_user
+        message = callback_query.message if isinstance(callback_query.message, Message) else None
+    else:
+        message = update.message
+        user = message.from_user if message else None
+    logger.error(
         "Telegram update handling failed",
-        extra={"error": str(event.exception)},
+        exc_info=event.exception,
+        extra={
+            "update_id": update.update_id,
+            "userID": user.id if user else None,
+            "message_id": message.message_id if message else None,
+            "error": str(event.exception),
+        },
     )
 
-    update = event.update
-    if update.message is None:
+    if callback_query:
+        try:
+            # Fails if the handler answered the query before the error.
+            await callback_query.answer()
+        except Exception as exc:
+            logger.warning("Failed to answer callback query", extra={"error": str(exc)})
+
+    if message is None:
         return
 
-    message = update.message
-    language = get_language(message.from_user)
+    language = get_language(user)
     try:
         await message.reply(translate("telegram.error.general", locale=language))
     except Exception as exc:
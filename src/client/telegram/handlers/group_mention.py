@@ -0,0 +1,44 @@
+from aiogram import Bot
+from aiogram.enums import ChatType, MessageEntityType
+from aiogram.filters import Filter
+from aiogram.types import Message, User
+
+from src.config import Settings
+
+
+class AddressedToBotFilter(Filter):
+    """
+    Passes messages meant for the bot.
+
+    Private messages always are. With ``telegram_group_mention_only``, group messages are only
+    if they @mention the bot or reply to one of its messages, so the bot does not react to every
+    link posted in a busy group.
+    """
+
+    async def __call__(self, message: Message, bot: Bot, settings: Settings) -> bool:
+        if not settings.telegram_group_mention_only or message.chat.type == ChatType.PRIVATE:
+            return True
+        # Bot.me() calls getMe once and caches the bot's own user for later messages.
+        me = await bot.me()
+        return _is_reply_to(message, me) or _mentions(message, me)
+
+
+def _is_reply_to(message: Message, user: User) -> bool:
+    """Returns whether the message replies to a message of the given user."""
+    reply = message.reply_to_message
+    return reply is not None and reply.from_user is not None and reply.from_user.id == user.id
+
+
+def _mentions(message: Message, user: User) -> bool:
+    """Returns whether the message text or caption mentions the given user, by @username or as a text mention."""
+    if message.text is not None:
+        text, entities = message.text, message.entities
+    else:
+        text, entities = message.caption or "", message.caption_entities
+    mention = f"@{user.username}".lower() if user.username else None
+    for entity in entities or ():
+        if entity.type == MessageEntityType.MENTION and entity.extract_from(text).lower() == mention:
+            return True
+        if entity.type == MessageEntityType.TEXT_MENTION and entity.user is not None and entity.user.id == user.id:
+            return True
+    return False
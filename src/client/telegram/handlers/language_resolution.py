@@ -0,0 +1,29 @@
+from aiogram.enums import ChatType
+from aiogram.types import Chat, User
+
+from src.language_preferences import UserLanguagePreferences
+from src.localization import normalize_locale
+
+
+def get_language(user: User | None) -> str:
+    """Retrieves the language code for a given user, if available."""
+    return normalize_locale(user.language_code if user else None)
+
+
+async def get_preferred_language(user: User | None, language_preferences: UserLanguagePreferences, chat: Chat | None = None) -> str:
+    """
+    Retrieves the language summaries are written in for a user.
+
+    In a group chat with a default language set by /setlang, that language is used, so members do
+    not get summaries in whatever language each of them happens to use. Otherwise it is the language
+    the user chose with /language, falling back to their Telegram language.
+    """
+    if chat is not None and chat.type != ChatType.PRIVATE:
+        chat_language = await language_preferences.get_chat(chat.id)
+        if chat_language:
+            return chat_language
+    if user is not None:
+        preferred = await language_preferences.get(user.id)
+        if preferred:
+            return preferred
+    return get_language(user)
@@ -0,0 +1,17 @@
+from src.load.errors import InvalidVideoUrlError, NoSubtitlesError, VideoLoadError, VideoPrivateError, VideoUnavailableError
+
+# Replies to the transcript load failures users can act on; any other failure gets telegram.error.transcript_failed.
+LOAD_ERROR_KEYS: dict[type[VideoLoadError], str] = {
+    InvalidVideoUrlError: "telegram.error.no_url_found",
+    VideoPrivateError: "telegram.error.video_private",
+    VideoUnavailableError: "telegram.error.video_unavailable",
+    NoSubtitlesError: "telegram.error.no_subtitles",
+}
+
+
+def load_error_key(exc: Exception) -> str:
+    """Returns the locale key of the reply telling a user why the transcript of their video could not be loaded."""
+    for error_type, key in LOAD_ERROR_KEYS.items():
+        if isinstance(exc, error_type):
+            return key
+    return "telegram.error.transcript_failed"
@@ -1,44 +1,64 @@
 import logging
 
 from aiogram import F, Router
-from aiogram.types import LinkPreviewOptions, Message
+from aiogram.types import Message
 
-from src.client.telegram.handlers.helpers import get_language
+from src.client.telegram.handlers.group_mention import AddressedToBotFilter
+from src.client.telegram.handlers.language_resolution import get_preferred_language
+from src.client.telegram.handlers.load_error_replies import load_error_key
+from src.client.telegram.handlers.pasted_text import is_pasted_transcript, summarize_pasted_text
+from src.client.telegram.handlers.progress import ProgressTracker
+from src.client.telegram.handlers.short_transcript import reject_short_transcript
+from src.client.telegram.handlers.style_keyboard import SummarySourceStore, build_style_keyboard
+from src.client.telegram.handlers.summary_reply import reply_with_summary
+from src.client.telegram.handlers.video_picker import build_video_picker
 from src.config import Settings
-from src.load.video_loader import VideoDataLoader
-from src.load.video_provider import extract_urls
+from src.language_preferences import UserLanguagePreferences
+from src.load.url_extraction import extract_canonical_urls
+from src.load.video_loader_factory import ProviderVideoLoader
+from src.load.video_provider import find_video_id
 from src.localization import translate
+from src.log_context import add_log_context
 from src.rate_limiter import UserRateLimiter
-from src.transform.summarization import OpenAISummarizer
-from src.utils.markdown import markdown_to_telegram_html
-from src.utils.text import to_lexical_chunks
+from src.transform.chapter_summary import summarize_transcript
+from src.transform.summarization import OpenAISummarizer, SummarizationTimeoutError, SummaryUnavailableError
 
 logger = logging.getLogger(__name__)
 
 message_router = Router()
+# Commands are routed elsewhere, so they keep working in groups without a mention.
+message_router.message.filter(AddressedToBotFilter())
 
 
-@message_router.message(F.text & ~F.text.startswith("/"))
-async def handle_message(  # noqa: C901, PLR0911
+@message_router.message((F.text & ~F.text.startswith("/")) | F.caption)
+async def handle_message(  # noqa: C901, PLR0911, PLR0913
     message: Message,
-    loader: VideoDataLoader,
+    loader: ProviderVideoLoader,
     summarizer: OpenAISummarizer,
     rate_limiter: UserRateLimiter,
     settings: Settings,
+    source_store: SummarySourceStore,
+    language_preferences: UserLanguagePreferences,
 ) -> None:
-    """Extracts URLs, loads video transcripts, summarizes them, and sends the summary back to the user."""
+    """
+    Extracts URLs, loads video transcripts, summarizes them, and sends the summary back to the user.
 
+    The caption is used for media messages, e.g. a forwarded video post with a link.
+    Long messages without a URL are summarized directly as a pasted transcript.
+    Messages with links to several videos get a keyboard to pick the one to summarize.
+    """
     user = message.from_user
     if user is None:
         return
 
-    language = get_language(user)
+    language = await get_preferred_language(user, language_preferences, message.chat)
     if user.is_bot:
         logger.warning("Ignored bot message", extra={"userID": user.id})
         return
 
-    if message.text is None:
-        logger.warning("Got no message from ", extra={"userID": user.id})
+    text = message.text or message.caption
+    if text is None:
+        logger.warning("Got no message text", extra={"userID": user.id})
         return
 
     logger.info(
@@ -69,8 +89,11 @@ async def handle_message(  # noqa: C901, PLR0911
         )
         return
 
-    text = message.text or ""
-    urls = extract_urls(text)
+    urls = extract_canonical_urls(text)
+    if not urls and is_pasted_transcript(text, settings.pasted_text_min_length):
+        await summarize_pasted_text(message, text, language, summarizer, settings)
+        return
+
     if not urls:
         logger.info(
             "No URL found in message",
@@ -78,7 +101,7 @@ async def handle_message(  # noqa: C901, PLR0911
                 "userID": user.id,
                 "username": user.username,
                 "message_id": message.message_id,
-                "text": message.text,
+                "text": text,
             },
         )
         await message.reply(translate("telegram.error.no_url_found", locale=language))
@@ -96,10 +119,53 @@ async def handle_message(  # noqa: C901, PLR0911
                 "url_count": len(urls),
             },
         )
-        await processing_message.edit_text(translate("telegram.error.multiple_urls", locale=language))
+        reply_markup = await build_video_picker(urls, source_store)
+        await processing_message.edit_text(translate("telegram.error.multiple_urls", locale=language), reply_markup=reply_markup)
+        return
+
+    await summarize_video_url(message, processing_message, urls[0], language, loader, summarizer, settings, source_store)
+
+
+@message_router.message(F.voice | F.video_note | F.audio | F.sticker | F.photo | F.video | F.animation | F.document)
+async def handle_unsupported_message(message: Message, language_preferences: UserLanguagePreferences) -> None:
+    """Tells the user that media without a captioned link can't be summarized."""
+
+    user = message.from_user
+    if user is None or user.is_bot:
         return
 
-    video_url = urls[0]
+    language = await get_preferred_language(user, language_preferences, message.chat)
+    logger.info(
+        "Unsupported message type",
+        extra={"userID": user.id, "username": user.username, "message_id": message.message_id, "content_type": message.content_type},
+    )
+    await message.reply(translate("telegram.error.unsupported_message", locale=language))
+
+
+async def summarize_video_url(  # noqa: PLR0913
+    message: Message,
+    processing_message: Message,
+    video_url: str,
+    language: str,
+    loader: ProviderVideoLoader,
+    summarizer: OpenAISummarizer,
+    settings: Settings,
+    source_store: SummarySourceStore,
+    refresh: bool = False,
+) -> None:
+    """
+    Loads the transcript of a video, summarizes it and replies to the message with the summary.
+
+    Progress is reported by editing ``processing_message``, which is deleted once the summary is sent.
+    With ``refresh``, the cached transcript and summary are bypassed and replaced.
+    """
+
+    user = message.from_user
+    if user is None:
+        return
+
+    video_id = find_video_id(video_url) or video_url
+    add_log_context(videoID=video_id, correlation_id=f"{user.id}:{video_id}")
     logger.info(
         "Processing video URL",
         extra={
@@ -110,12 +176,13 @@ async def handle_message(  # noqa: C901, PLR0911
         },
     )
 
-    await processing_message.edit_text(translate("telegram.progress.fetching_info", locale=language))
+    progress = ProgressTracker(processing_message, language, settings.progress_style)
+    await progress.step("telegram.progress.fetching_info")
 
     transcript = None
 
     try:
-        transcript = await loader.load(video_url)
+        transcript = await loader.load(video_url, refresh=refresh)
     except Exception as exc:
         logger.exception(
             "Failed to load transcript",
@@ -127,7 +194,7 @@ async def handle_message(  # noqa: C901, PLR0911
                 "error": str(exc),
             },
         )
-        await processing_message.edit_text(translate("telegram.error.transcript_failed", locale=language))
+        await processing_message.edit_text(translate(load_error_key(exc), locale=language))
         return
 
     logger.info(
@@ -139,10 +206,39 @@ async def handle_message(  # noqa: C901, PLR0911
         },
     )
 
-    await processing_message.edit_text(translate("telegram.progress.summarizing", locale=language))
+    if await reject_short_transcript(message, processing_message, video_url, transcript, language, settings):
+        return
+
+    await progress.step("telegram.progress.summarizing")
 
     try:
-        summary = await summarizer.summarize(transcript.transcript, language)
+        summary = await summarize_transcript(
+            summarizer, transcript, language, by_chapters=settings.feature_flags.chapter_summaries, refresh=refresh
+        )
+    except SummarizationTimeoutError as exc:
+        logger.warning(
+            "Summarization timed out",
+            extra={
+                "userID": user.id,
+                "username": user.username,
+                "message_id": message.message_id,
+                "error": str(exc),
+            },
+        )
+        await processing_message.edit_text(translate("telegram.error.summary_timeout", locale=language))
+        return
+    except SummaryUnavailableError as exc:
+        logger.warning(
+            "No usable summary from the model",
+            extra={
+                "userID": user.id,
+                "username": user.username,
+                "message_id": message.message_id,
+                "error": str(exc),
+            },
+        )
+        await processing_message.edit_text(translate("telegram.error.summary_unavailable", locale=language))
+        return
     except Exception as exc:
         logger.exception(
             "Failed to summarize transcript",
@@ -166,28 +262,12 @@ async def handle_message(  # noqa: C901, PLR0911
         },
     )
 
-    title = translate(
-        "telegram.response.title",
-        locale=language,
-        title=transcript.title,
-        url=video_url,
-    )
-    response = f"{title}\n{summary}".strip()
-    chunks = to_lexical_chunks(response, settings.max_telegram_message_length)
-    for i, chunk in enumerate(chunks):
-        logger.debug(
-            "Sending response chunk",
-            extra={
-                "userID": user.id,
-                "username": user.username,
-                "message_id": message.message_id,
-                "chunk_index": i,
-            },
-        )
-        await message.reply(
-            text=markdown_to_telegram_html(chunk),
-            link_preview_options=LinkPreviewOptions(is_disabled=False, url=video_url, show_above_text=True, prefer_small_media=True),
-        )
+    reply_markup = None
+    if settings.feature_flags.style_keyboard:
+        token = await source_store.remember(video_url)
+        reply_markup = build_style_keyboard(token, language)
+
+    await reply_with_summary(message, video_url, transcript, summary, language, settings, reply_markup=reply_markup)
 
     logger.info(
         "Response sent",
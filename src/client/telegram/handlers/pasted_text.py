@@ -0,0 +1,64 @@
+import logging
+
+from aiogram.types import Message
+
+from src.client.telegram.handlers.summary_reply import reply_with_text_summary
+from src.config import Settings
+from src.localization import translate
+from src.transform.summarization import OpenAISummarizer, SummarizationTimeoutError, SummaryUnavailableError
+
+logger = logging.getLogger(__name__)
+
+
+def is_pasted_transcript(text: str, min_length: int) -> bool:
+    """
+    Decides whether a message without a video link should be summarized as pasted text.
+
+    Args:
+        text: The message text.
+        min_length: Minimum length in characters; 0 disables pasted text summaries.
+
+    Returns:
+        True if the text is long enough to be summarized directly.
+    """
+    return min_length > 0 and len(text.strip()) >= min_length
+
+
+async def summarize_pasted_text(message: Message, text: str, language: str, summarizer: OpenAISummarizer, settings: Settings) -> None:
+    """Summarizes a transcript pasted into the chat instead of a video link."""
+
+    user_id = message.from_user.id if message.from_user else None
+    logger.info(
+        "Summarizing pasted text",
+        extra={
+            "userID": user_id,
+            "message_id": message.message_id,
+            "text_length": len(text),
+        },
+    )
+    processing_message = await message.reply(translate("telegram.progress.summarizing", locale=language))
+
+    try:
+        summary = await summarizer.summarize(text, language)
+    except SummarizationTimeoutError as exc:
+        logger.warning("Pasted text summarization timed out", extra={"userID": user_id, "error": str(exc)})
+        await processing_message.edit_text(translate("telegram.error.summary_timeout", locale=language))
+        return
+    except SummaryUnavailableError as exc:
+        logger.warning("No usable summary from the model", extra={"userID": user_id, "error": str(exc)})
+        await processing_message.edit_text(translate("telegram.error.summary_unavailable", locale=language))
+        return
+    except Exception as exc:
+        logger.exception("Failed to summarize pasted text", extra={"userID": user_id, "error": str(exc)})
+        await processing_message.edit_text(translate("telegram.error.summary_failed", locale=language))
+        return
+
+    await reply_with_text_summary(message, summary, language, settings)
+
+    try:
+        await processing_message.delete()
+    except Exception as exc:
+        logger.exception(
+            "Failed to delete processing message",
+            extra={"userID": user_id, "message_id": message.message_id, "error": str(exc)},
+        )
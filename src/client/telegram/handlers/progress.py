@@ -0,0 +1,48 @@
+from aiogram.types import Message
+
+from src.localization import translate
+
+DONE_MARK = "✅"
+
+
+class ProgressTracker:
+    """
+    Reports the steps of a long request by editing the bot's processing message.
+
+    With the ``replace`` style each step replaces the previous text. With ``append``, completed
+    steps stay listed with a checkmark above the current one, so users can see what is done.
+    """
+
+    def __init__(self, message: Message, locale: str, style: str) -> None:
+        """
+        Initialize the tracker.
+
+        Args:
+            message: The processing message to edit.
+            locale: Locale of the step texts.
+            style: ``replace`` or ``append``.
+        """
+        self.message = message
+        self.locale = locale
+        self.style = style
+        self.steps: list[str] = []
+
+    async def step(self, key: str) -> None:
+        """Mark the previous step done and show the step with the given translation key as current."""
+        self.steps.append(translate(key, locale=self.locale))
+        await self.message.edit_text(self.render())
+
+    def render(self) -> str:
+        """Return the progress text for the steps so far."""
+        if self.style != "append":
+            return self.steps[-1]
+        done = [f"{DONE_MARK} {_step_label(step)}" for step in self.steps[:-1]]
+        return "\n".join([*done, self.steps[-1]])
+
+
+def _step_label(text: str) -> str:
+    """Strip the leading icon and trailing ellipsis of a progress text, e.g. "🔍 Getting info..." -> "Getting info"."""
+    icon, _, label = text.partition(" ")
+    if label and not any(char.isalnum() for char in icon):
+        text = label
+    return text.rstrip(".…").strip()
@@ -0,0 +1,88 @@
+import logging
+
+from aiogram import Router
+from aiogram.filters import Command, CommandObject
+from aiogram.types import Message
+
+from src.client.telegram.handlers.language_resolution import get_preferred_language
+from src.client.telegram.handlers.messages import summarize_video_url
+from src.client.telegram.handlers.style_keyboard import SummarySourceStore, SummaryStyleCallback
+from src.config import Settings
+from src.language_preferences import UserLanguagePreferences
+from src.load.video_loader_factory import ProviderVideoLoader
+from src.load.url_extraction import extract_canonical_urls
+from src.localization import translate
+from src.rate_limiter import UserRateLimiter
+from src.transform.summarization import OpenAISummarizer
+
+logger = logging.getLogger(__name__)
+
+refresh_router = Router()
+
+
+@refresh_router.message(Command("refresh"))
+async def refresh_command(  # noqa: PLR0913
+    message: Message,
+    command: CommandObject,
+    loader: ProviderVideoLoader,
+    summarizer: OpenAISummarizer,
+    rate_limiter: UserRateLimiter,
+    settings: Settings,
+    source_store: SummarySourceStore,
+    language_preferences: UserLanguagePreferences,
+) -> None:
+    """
+    Handles /refresh, summarizing a video again without the cached transcript and summary.
+
+    Meant as a reply to a summary or to a message with a video link, e.g. once a premiere has
+    ended or a video was re-uploaded; the new transcript and summary replace the cached ones.
+    """
+    user = message.from_user
+    if user is None:
+        return
+
+    language = await get_preferred_language(user, language_preferences, message.chat)
+    video_url = await find_video_to_refresh(message, command, source_store)
+    if video_url is None:
+        await message.reply(translate("telegram.refresh.usage", locale=language))
+        return
+
+    if await rate_limiter.is_limited(user.id):
+        logger.warning("Rate Limit exceeded", extra={"userID": user.id, "username": user.username, "language": language})
+        await message.reply(translate("telegram.error.rate_limited", locale=language, rateLimitWindow=settings.rate_limit_window_seconds))
+        return
+
+    logger.info("Refreshing summary", extra={"userID": user.id, "username": user.username, "url": video_url})
+    processing_message = await message.reply(translate("telegram.progress.processing", locale=language))
+    await summarize_video_url(message, processing_message, video_url, language, loader, summarizer, settings, source_store, refresh=True)
+
+
+async def find_video_to_refresh(message: Message, command: CommandObject, source_store: SummarySourceStore) -> str | None:
+    """
+    Finds the video /refresh is about.
+
+    That is a link after the command, else a link in the replied-to message, else the video behind
+    the style buttons of a replied-to summary.
+
+    Returns:
+        The canonical video URL, or None if there is none or the summary's buttons have expired.
+    """
+    urls = extract_canonical_urls(command.args or "")
+    reply = message.reply_to_message
+    if not urls and reply is not None:
+        urls = extract_canonical_urls(reply.text or reply.caption or "")
+    if urls:
+        return urls[0]
+
+    if reply is None or reply.reply_markup is None:
+        return None
+    for row in reply.reply_markup.inline_keyboard:
+        for button in row:
+            if not button.callback_data:
+                continue
+            try:
+                callback_data = SummaryStyleCallback.unpack(button.callback_data)
+            except ValueError:
+                continue
+            return await source_store.resolve(callback_data.token)
+    return None
@@ -0,0 +1,46 @@
+import logging
+
+from aiogram.types import Message
+
+from src.client.telegram.handlers.transcript import reply_with_transcript
+from src.config import Settings
+from src.load.video_loader import VideoTranscript
+from src.localization import translate
+
+logger = logging.getLogger(__name__)
+
+
+async def reject_short_transcript(  # noqa: PLR0913
+    message: Message,
+    processing_message: Message,
+    video_url: str,
+    transcript: VideoTranscript,
+    language: str,
+    settings: Settings,
+) -> bool:
+    """
+    Stops transcripts shorter than ``settings.min_transcript_chars`` from being summarized.
+
+    The user is told the video is too short to summarize; with the ``short_transcript_text``
+    feature flag the transcript itself is sent as well.
+
+    Returns:
+        True if the transcript was too short and the user was answered, False if it can be summarized.
+    """
+    text = transcript.transcript.strip()
+    if len(text) >= settings.min_transcript_chars:
+        return False
+
+    logger.info(
+        "Transcript too short to summarize",
+        extra={
+            "userID": message.from_user.id if message.from_user else None,
+            "message_id": message.message_id,
+            "length": len(text),
+            "min_chars": settings.min_transcript_chars,
+        },
+    )
+    await processing_message.edit_text(translate("telegram.error.transcript_too_short", locale=language))
+    if settings.feature_flags.short_transcript_text and text:
+        await reply_with_transcript(message, video_url, transcript, language, settings)
+    return True
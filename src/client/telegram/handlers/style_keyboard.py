@@ -0,0 +1,62 @@
+import hashlib
+
+from aiogram.filters.callback_data import CallbackData
+from aiogram.types import InlineKeyboardMarkup
+from aiogram.utils.keyboard import InlineKeyboardBuilder
+
+from src.cache import CacheProvider
+from src.localization import translate
+from src.transform.summary_style import SummaryStyle
+
+cache_prefix = "source:"
+
+STYLE_BUTTONS: tuple[SummaryStyle, ...] = (SummaryStyle.SHORT, SummaryStyle.DETAILED, SummaryStyle.BULLETS)
+
+
+class SummaryStyleCallback(CallbackData, prefix="style"):
+    """Callback payload of a summary style button."""
+
+    style: SummaryStyle
+    token: str
+
+
+class SummarySourceStore:
+    """
+    Remembers which video a summary was built from.
+
+    Telegram limits callback data to 64 bytes, so style buttons carry a short
+    token that resolves back to the video URL through the cache provider.
+    """
+
+    def __init__(self, provider: CacheProvider, ttl_seconds: int) -> None:
+        """
+        Initializes the SummarySourceStore.
+
+        Args:
+            provider: The cache provider for state management.
+            ttl_seconds: How long a source stays resolvable.
+        """
+        self.provider = provider
+        self.ttl_seconds = ttl_seconds
+
+    async def remember(self, url: str) -> str:
+        """Stores the video URL and returns its token."""
+        token = hashlib.sha256(url.encode("utf-8")).hexdigest()[:16]
+        await self.provider.put(f"{cache_prefix}:{token}", url, self.ttl_seconds)
+        return token
+
+    async def resolve(self, token: str) -> str | None:
+        """Returns the video URL for a token, or None if it has expired."""
+        return await self.provider.get(f"{cache_prefix}:{token}")
+
+
+def build_style_keyboard(token: str, language: str) -> InlineKeyboardMarkup:
+    """Builds the inline keyboard offering to re-summarize a video in another style."""
+    builder = InlineKeyboardBuilder()
+    for style in STYLE_BUTTONS:
+        builder.button(
+            text=translate(f"telegram.style.{style.value}", locale=language),
+            callback_data=SummaryStyleCallback(style=style, token=token),
+        )
+    builder.adjust(len(STYLE_BUTTONS))
+    return builder.as_markup()
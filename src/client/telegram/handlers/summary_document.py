@@ -0,0 +1,71 @@
+import re
+
+from aiogram.types import BufferedInputFile
+
+from src.load.video_loader import VideoTranscript
+from src.transform.summary_markdown import render_summary_markdown
+
+_FILENAME_UNSAFE = re.compile(r"[^\w\-]+")
+_MAX_FILENAME_STEM_LENGTH = 64
+_DEFAULT_FILENAME_STEM = "summary"
+
+
+def should_send_as_file(summary: str, threshold: int) -> bool:
+    """
+    Decides whether a summary is long enough to be sent as a document.
+
+    Args:
+        summary: The summary text.
+        threshold: Length in characters above which a document is sent; 0 disables documents.
+
+    Returns:
+        True if the summary should be sent as a file.
+    """
+    return threshold > 0 and len(summary) > threshold
+
+
+def build_summary_filename(title: str, suffix: str = ".md") -> str:
+    """
+    Builds a filesystem-safe filename from a video title.
+
+    Args:
+        title: The video title.
+        suffix: File extension, including the dot.
+
+    Returns:
+        Filename such as ``My_Video.md``.
+    """
+    stem = _FILENAME_UNSAFE.sub("_", title).strip("_")[:_MAX_FILENAME_STEM_LENGTH].rstrip("_")
+    return f"{stem or _DEFAULT_FILENAME_STEM}{suffix}"
+
+
+def build_summary_document(video_url: str, transcript: VideoTranscript, summary: str) -> BufferedInputFile:
+    """
+    Renders a summary as a Markdown document headed by the linked video title, uploader and thumbnail.
+
+    Args:
+        video_url: The summarized video URL.
+        transcript: Transcript of the summarized video.
+        summary: The summary text.
+
+    Returns:
+        Document ready to be sent with ``reply_document``.
+    """
+    content = render_summary_markdown(video_url, transcript, summary)
+    return BufferedInputFile(content.encode("utf-8"), filename=build_summary_filename(transcript.title))
+
+
+def build_transcript_document(video_url: str, title: str, transcript: str) -> BufferedInputFile:
+    """
+    Renders a cleaned transcript as a plain text document with the video title and URL on top.
+
+    Args:
+        video_url: The video URL.
+        title: The video title.
+        transcript: The cleaned transcript text.
+
+    Returns:
+        Document ready to be sent with ``reply_document``.
+    """
+    content = f"{title}\n{video_url}\n\n{transcript.strip()}\n"
+    return BufferedInputFile(content.encode("utf-8"), filename=build_summary_filename(title, ".txt"))
@@ -0,0 +1,181 @@
+import logging
+from collections.abc import Callable
+from functools import partial
+
+from aiogram.types import InlineKeyboardMarkup, LinkPreviewOptions, Message
+
+from src.client.telegram.handlers.summary_document import build_summary_document, should_send_as_file
+from src.client.telegram.retry import send_with_retry
+from src.config import Settings
+from src.load.video_loader import VideoTranscript
+from src.localization import translate
+from src.utils.markdown import markdown_to_telegram_html, telegram_html_to_text
+from src.utils.text import format_duration, to_lexical_chunks
+
+logger = logging.getLogger(__name__)
+
+# Smallest chunk budget split_into_messages shrinks to while fitting converted HTML into a message
+MIN_CHUNK_LENGTH = 100
+NO_LINK_PREVIEW = LinkPreviewOptions(is_disabled=True)
+
+
+async def reply_with_summary(  # noqa: PLR0913
+    message: Message,
+    video_url: str,
+    transcript: VideoTranscript,
+    summary: str,
+    language: str,
+    settings: Settings,
+    reply_markup: InlineKeyboardMarkup | None = None,
+) -> None:
+    """
+    Replies to a message with a summary under the configured header.
+
+    The summary is split into chunks that fit a Telegram message, or sent as a
+    Markdown document when it is longer than ``settings.send_as_file_threshold``.
+    """
+
+    header = build_summary_header(video_url, transcript, language, settings)
+    if should_send_as_file(summary, settings.send_as_file_threshold):
+        logger.debug(
+            "Sending response as document",
+            extra={"message_id": message.message_id, "length": len(summary)},
+        )
+        document = build_summary_document(video_url, transcript, summary)
+        caption = markdown_to_telegram_html(header) if header else None
+        await send_with_retry(
+            partial(message.reply_document, document, caption=caption, reply_markup=reply_markup),
+            plain_text_fallback=partial(
+                message.reply_document,
+                document,
+                caption=telegram_html_to_text(caption) if caption else None,
+                parse_mode=None,
+                reply_markup=reply_markup,
+            ),
+        )
+        return
+
+    link_preview_options = LinkPreviewOptions(
+        is_disabled=not settings.feature_flags.link_preview,
+        url=video_url,
+        show_above_text=True,
+        prefer_small_media=True,
+    )
+    messages = split_into_messages(f"{header}\n{summary}".strip(), language, settings.max_telegram_message_length)
+    await _reply_in_chunks(message, messages, link_preview_options, reply_markup)
+
+
+def build_summary_header(video_url: str, transcript: VideoTranscript, language: str, settings: Settings) -> str:
+    """
+    Renders the header shown above a summary from the ``settings.summary_header_key`` template.
+
+    Templates get ``title``, ``url``, ``uploader`` and ``duration``; the header is empty when disabled.
+    """
+    if not settings.feature_flags.summary_header:
+        return ""
+    return translate(
+        settings.summary_header_key,
+        locale=language,
+        title=transcript.title,
+        url=video_url,
+        uploader=transcript.uploader,
+        duration=format_duration(transcript.duration),
+    )
+
+
+async def reply_with_text_summary(message: Message, summary: str, language: str, settings: Settings) -> None:
+    """Replies to a message with a summary of pasted text, split into chunks that fit a Telegram message."""
+    messages = split_into_messages(summary.strip(), language, settings.max_telegram_message_length)
+    await _reply_in_chunks(message, messages, NO_LINK_PREVIEW, None)
+
+
+def split_into_messages(
+    response: str,
+    language: str,
+    max_length: int,
+    render: Callable[[str], str] | None = None,
+) -> list[str]:
+    """
+    Splits a response into rendered Telegram messages of at most ``max_length`` characters.
+
+    Rendering (Markdown to HTML, or escaping) makes text longer, so the limit is checked
+    on the rendered messages: while one is too long, the response is split again with
+    the overflow taken off the chunk budget.
+
+    Args:
+        response: Text to send.
+        language: Locale of the "Part i/n" labels.
+        max_length: Maximum length of a rendered message.
+        render: Converts a chunk to the text sent with the HTML parse mode; Markdown to HTML by default.
+
+    Returns:
+        Rendered messages in order.
+    """
+    render = render or markdown_to_telegram_html
+    budget = max_length
+    while True:
+        messages = [render(part) for part in split_into_parts(response, language, budget)]
+        overflow = max(len(message) for message in messages) - max_length
+        if overflow <= 0 or budget == MIN_CHUNK_LENGTH:
+            return messages
+        budget = max(budget - overflow, MIN_CHUNK_LENGTH)
+
+
+def split_into_parts(response: str, language: str, max_length: int) -> list[str]:
+    """
+    Splits a response into chunks of at most ``max_length`` characters.
+
+    When more than one chunk is needed, each one starts with a localized "Part i/n"
+    label, so replies arriving out of order can still be read in sequence. The label
+    counts towards ``max_length``.
+    """
+    chunks = to_lexical_chunks(response, max_length)
+    total = len(chunks)
+    if total <= 1:
+        return chunks
+
+    # A longer label leaves less room per chunk, which can add chunks and widen the label again.
+    while True:
+        label_length = len(_part_label(total, total, language)) + 1
+        chunks = to_lexical_chunks(response, max_length - label_length)
+        if len(chunks) == total:
+            break
+        total = len(chunks)
+    return [f"{_part_label(i, total, language)}\n{chunk}" for i, chunk in enumerate(chunks, start=1)]
+
+
+def _part_label(part: int, total: int, language: str) -> str:
+    """Returns the localized "Part i/n" label of a chunk."""
+    return translate("telegram.response.part", locale=language, part=part, total=total)
+
+
+async def _reply_in_chunks(
+    message: Message,
+    chunks: list[str],
+    link_preview_options: LinkPreviewOptions,
+    reply_markup: InlineKeyboardMarkup | None,
+) -> None:
+    """
+    Sends rendered chunks as consecutive replies, attaching the keyboard to the last one.
+
+    Only the first chunk uses ``link_preview_options``; repeating the video preview
+    above every following chunk would push their text below it. A chunk Telegram
+    cannot parse as HTML is sent as plain text instead.
+    """
+    for i, chunk in enumerate(chunks):
+        logger.debug(
+            "Sending response chunk",
+            extra={
+                "message_id": message.message_id,
+                "chunk_index": i,
+            },
+        )
+        reply = partial(
+            message.reply,
+            link_preview_options=NO_LINK_PREVIEW if i else link_preview_options,
+            reply_markup=reply_markup if i == len(chunks) - 1 else None,
+        )
+        await send_with_retry(
+            partial(reply, text=chunk),
+            plain_text_fallback=partial(reply, text=telegram_html_to_text(chunk), parse_mode=None),
+        )
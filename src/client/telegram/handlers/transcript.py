@@ -0,0 +1,98 @@
+import html
+import logging
+from functools import partial
+
+from aiogram import Router
+from aiogram.filters import Command, CommandObject
+from aiogram.types import Message
+
+from src.client.telegram.handlers.language_resolution import get_preferred_language
+from src.client.telegram.handlers.load_error_replies import load_error_key
+from src.client.telegram.handlers.summary_document import build_transcript_document, should_send_as_file
+from src.client.telegram.handlers.summary_reply import NO_LINK_PREVIEW, split_into_messages
+from src.client.telegram.retry import send_with_retry
+from src.config import Settings
+from src.language_preferences import UserLanguagePreferences
+from src.load.url_extraction import extract_canonical_urls
+from src.load.video_loader import VideoTranscript
+from src.load.video_loader_factory import ProviderVideoLoader
+from src.localization import translate
+from src.rate_limiter import UserRateLimiter
+from src.utils.markdown import markdown_to_telegram_html, telegram_html_to_text
+
+logger = logging.getLogger(__name__)
+
+# Transcripts that need more messages than this are sent as a file instead
+MAX_TRANSCRIPT_MESSAGES = 3
+
+transcript_router = Router()
+
+
+@transcript_router.message(Command("transcript"))
+async def transcript_command(  # noqa: PLR0913
+    message: Message,
+    command: CommandObject,
+    loader: ProviderVideoLoader,
+    rate_limiter: UserRateLimiter,
+    settings: Settings,
+    language_preferences: UserLanguagePreferences,
+) -> None:
+    """Handles /transcript <url>, replying with the cleaned transcript of a video without summarizing it."""
+    user = message.from_user
+    if user is None:
+        return
+
+    language = await get_preferred_language(user, language_preferences, message.chat)
+    urls = extract_canonical_urls(command.args or "")
+    if not urls:
+        await message.reply(translate("telegram.transcript.usage", locale=language))
+        return
+
+    if await rate_limiter.is_limited(user.id):
+        logger.warning("Rate Limit exceeded", extra={"userID": user.id, "username": user.username, "language": language})
+        await message.reply(translate("telegram.error.rate_limited", locale=language, rateLimitWindow=settings.rate_limit_window_seconds))
+        return
+
+    video_url = urls[0]
+    logger.info("Sending transcript", extra={"userID": user.id, "username": user.username, "url": video_url})
+    processing_message = await message.reply(translate("telegram.progress.fetching_transcript", locale=language))
+
+    try:
+        transcript = await loader.load(video_url)
+    except Exception as exc:
+        logger.exception("Failed to load transcript", extra={"userID": user.id, "url": video_url, "error": str(exc)})
+        await processing_message.edit_text(translate(load_error_key(exc), locale=language))
+        return
+
+    await reply_with_transcript(message, video_url, transcript, language, settings)
+
+    try:
+        await processing_message.delete()
+    except Exception as exc:
+        logger.exception("Failed to delete processing message", extra={"userID": user.id, "error": str(exc)})
+
+
+async def reply_with_transcript(message: Message, video_url: str, transcript: VideoTranscript, language: str, settings: Settings) -> None:
+    """
+    Replies with a transcript as plain text under the video title.
+
+    The transcript is split into chunks that fit a Telegram message, or sent as a text
+    document when it needs more than ``MAX_TRANSCRIPT_MESSAGES`` messages or is longer
+    than ``settings.send_as_file_threshold``.
+    """
+    header = markdown_to_telegram_html(translate("telegram.response.title", locale=language, title=transcript.title, url=video_url))
+    # Transcripts are plain text; escape them for the HTML parse mode
+    chunks = split_into_messages(transcript.transcript, language, settings.max_telegram_message_length - len(header) - 1, render=html.escape)
+    if len(chunks) > MAX_TRANSCRIPT_MESSAGES or should_send_as_file(transcript.transcript, settings.send_as_file_threshold):
+        logger.debug("Sending transcript as document", extra={"message_id": message.message_id, "length": len(transcript.transcript)})
+        document = build_transcript_document(video_url, transcript.title, transcript.transcript)
+        await send_with_retry(
+            partial(message.reply_document, document, caption=header),
+            plain_text_fallback=partial(message.reply_document, document, caption=telegram_html_to_text(header), parse_mode=None),
+        )
+        return
+
+    for i, chunk in enumerate(chunks):
+        text = chunk if i else f"{header}\n{chunk}"
+        reply = partial(message.reply, link_preview_options=NO_LINK_PREVIEW)
+        await send_with_retry(partial(reply, text=text), plain_text_fallback=partial(reply, text=telegram_html_to_text(text), parse_mode=None))
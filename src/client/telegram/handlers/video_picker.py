@@ -0,0 +1,44 @@
+from collections.abc import Sequence
+
+from aiogram.filters.callback_data import CallbackData
+from aiogram.types import InlineKeyboardMarkup
+from aiogram.utils.keyboard import InlineKeyboardBuilder
+
+from src.client.telegram.handlers.style_keyboard import SummarySourceStore
+from src.load.video_provider import find_provider, find_video_id
+
+# Longer lists are cut; a message with more links is most likely not a request to summarize all of them.
+MAX_PICKER_VIDEOS = 10
+
+
+class PickVideoCallback(CallbackData, prefix="pick"):
+    """Callback payload of a video button offered for a message with several links."""
+
+    token: str
+
+
+async def build_video_picker(urls: Sequence[str], source_store: SummarySourceStore) -> InlineKeyboardMarkup:
+    """
+    Builds the inline keyboard asking which of the videos in a message to summarize.
+
+    Args:
+        urls: Canonical video URLs in message order; only the first ``MAX_PICKER_VIDEOS`` are offered.
+        source_store: Store the buttons' tokens resolve through, as callback data is limited to 64 bytes.
+
+    Returns:
+        Keyboard with one button per video.
+    """
+    builder = InlineKeyboardBuilder()
+    for index, url in enumerate(urls[:MAX_PICKER_VIDEOS], start=1):
+        token = await source_store.remember(url)
+        builder.button(text=_button_text(index, url), callback_data=PickVideoCallback(token=token))
+    builder.adjust(1)
+    return builder.as_markup()
+
+
+def _button_text(index: int, url: str) -> str:
+    """Labels a video by its position, provider and ID, e.g. "1. YouTube: dQw4w9WgXcQ"."""
+    provider = find_provider(url)
+    if provider is None:
+        return f"{index}. {url}"
+    return f"{index}. {provider.name}: {find_video_id(url)}"
@@ -10,11 +10,29 @@ from aiogram.enums import ParseMode
 
 from src.cache.base import CacheProvider
 from src.cache.factory import get_cache_provider
-from src.client.telegram.handlers import commands_router, errors_router, messages_router
+from src.client.telegram.handlers import (
+    SummarySourceStore,
+    callbacks_router,
+    commands_router,
+    errors_router,
+    messages_router,
+    refresh_router,
+    transcript_router,
+)
+from src.client.telegram.middlewares import (
+    ChatAccessMiddleware,
+    ConcurrencyLimitMiddleware,
+    DeduplicationMiddleware,
+    LogContextMiddleware,
+    UpdateDeduplicationMiddleware,
+)
+from src.client.telegram.webhook import run_webhook
 from src.config import Settings
-from src.load.video_loader import VideoDataLoader
+from src.language_preferences import UserLanguagePreferences
+from src.load.video_loader_factory import ProviderVideoLoader
 from src.logger import configure_logging
 from src.rate_limiter import UserRateLimiter
+from src.transform.model_selection import ModelSelection
 from src.transform.summarization import OpenAISummarizer
 
 logger = logging.getLogger(__name__)
@@ -26,15 +44,32 @@ async def main() -> None:
     Configures logging, loads settings, initializes and runs the Telegram bot.
     """
     settings = Settings.from_env()
+    logger.info("Settings loaded", extra={"settings": settings.redacted()})
     provider: CacheProvider = get_cache_provider(settings)
 
     rate_limiter = UserRateLimiter(provider, settings.rate_limit_window_seconds)
-    loader = VideoDataLoader(settings)
-    summarizer = OpenAISummarizer(settings)
+    loader = ProviderVideoLoader(settings)
+    model_selection = ModelSelection(provider, settings)
+    summarizer = OpenAISummarizer(settings, model_selection)
+    source_store = SummarySourceStore(provider, settings.cache_transcript_ttl_seconds)
+    language_preferences = UserLanguagePreferences(provider)
 
     # aiogram setup
     dp = Dispatcher()
-    dp.include_routers(commands_router, messages_router, errors_router)
+    dp.include_routers(commands_router, transcript_router, refresh_router, messages_router, callbacks_router, errors_router)
+    # Outer middlewares run first, so even "busy" rejections are logged with the user context.
+    log_context = LogContextMiddleware()
+    dp.message.outer_middleware(log_context)
+    dp.callback_query.outer_middleware(log_context)
+    chat_access = ChatAccessMiddleware(settings.telegram_allowed_chat_ids, settings.telegram_allow_groups, settings.telegram_reply_not_allowed)
+    dp.message.outer_middleware(chat_access)
+    dp.callback_query.outer_middleware(chat_access)
+    # Telegram redelivers updates it did not see confirmed; handle each update and each message once.
+    dp.update.outer_middleware(UpdateDeduplicationMiddleware(provider))
+    dp.message.outer_middleware(DeduplicationMiddleware(provider))
+    concurrency_limit = ConcurrencyLimitMiddleware(settings.bot_max_concurrency)
+    dp.message.middleware(concurrency_limit)
+    dp.callback_query.middleware(concurrency_limit)
 
     session: AiohttpSession | None = None
     if settings.telegram_proxy_url:
@@ -46,13 +81,21 @@ async def main() -> None:
         default=DefaultBotProperties(parse_mode=ParseMode.HTML),
     )
 
-    await dp.start_polling(
-        bot,
-        settings=settings,
-        rate_limiter=rate_limiter,
-        loader=loader,
-        summarizer=summarizer,
-    )
+    dependencies = {
+        "rate_limiter": rate_limiter,
+        "loader": loader,
+        "summarizer": summarizer,
+        "source_store": source_store,
+        "language_preferences": language_preferences,
+        "model_selection": model_selection,
+    }
+    if settings.telegram_mode == "webhook":
+        await run_webhook(dp, bot, settings, **dependencies)
+        return
+
+    # Telegram refuses getUpdates while a webhook is set, e.g. after switching back from webhook mode.
+    await bot.delete_webhook()
+    await dp.start_polling(bot, settings=settings, **dependencies)
 
 
 if __name__ == "__main__":
@@ -0,0 +1,6 @@
+from src.client.telegram.middlewares.chat_access import ChatAccessMiddleware
+from src.client.telegram.middlewares.concurrency import ConcurrencyLimitMiddleware
+from src.client.telegram.middlewares.deduplication import DeduplicationMiddleware, UpdateDeduplicationMiddleware
+from src.client.telegram.middlewares.log_context import LogContextMiddleware
+
+__all__ = ["ChatAccessMiddleware", "ConcurrencyLimitMiddleware", "DeduplicationMiddleware", "LogContextMiddleware", "UpdateDeduplicationMiddleware"]
@@ -0,0 +1,53 @@
+import logging
+from collections.abc import Awaitable, Callable, Iterable
+from typing import Any
+
+from aiogram import BaseMiddleware
+from aiogram.enums import ChatType
+from aiogram.types import CallbackQuery, Chat, Message, TelegramObject
+
+from src.client.telegram.handlers.language_resolution import get_language
+from src.localization import translate
+
+logger = logging.getLogger(__name__)
+
+
+class ChatAccessMiddleware(BaseMiddleware):
+    """Ignores messages and button presses from chats the bot is not allowed to serve."""
+
+    def __init__(self, allowed_chat_ids: Iterable[int], allow_groups: bool, reply_when_denied: bool) -> None:
+        """
+        Initializes the middleware.
+
+        Args:
+            allowed_chat_ids: Chats the bot serves; empty serves every chat.
+            allow_groups: Whether group chats are served at all, even if listed.
+            reply_when_denied: Whether to tell users the bot is not available, instead of staying silent.
+        """
+        self.allowed_chat_ids = frozenset(allowed_chat_ids)
+        self.allow_groups = allow_groups
+        self.reply_when_denied = reply_when_denied
+
+    def is_allowed(self, chat: Chat) -> bool:
+        """Returns whether the bot serves the given chat."""
+        if chat.type != ChatType.PRIVATE and not self.allow_groups:
+            return False
+        return not self.allowed_chat_ids or chat.id in self.allowed_chat_ids
+
+    async def __call__(
+        self,
+        handler: Callable[[TelegramObject, dict[str, Any]], Awaitable[Any]],
+        event: TelegramObject,
+        data: dict[str, Any],
+    ) -> Any:
+        chat = event.message.chat if isinstance(event, CallbackQuery) and event.message else getattr(event, "chat", None)
+        if chat is None or self.is_allowed(chat):
+            return await handler(event, data)
+
+        logger.info("Ignoring update from a chat that is not allowed", extra={"chat_id": chat.id, "chat_type": chat.type})
+        if self.reply_when_denied:
+            if isinstance(event, Message):
+                await event.reply(translate("telegram.error.chat_not_allowed", locale=get_language(event.from_user)))
+            elif isinstance(event, CallbackQuery):
+                await event.answer(translate("telegram.error.chat_not_allowed", locale=get_language(event.from_user)), show_alert=True)
+        return None
@@ -0,0 +1,44 @@
+import asyncio
+import logging
+from collections.abc import Awaitable, Callable
+from typing import Any
+
+from aiogram import BaseMiddleware
+from aiogram.types import CallbackQuery, Message, TelegramObject
+
+from src.client.telegram.handlers.language_resolution import get_language
+from src.localization import translate
+
+logger = logging.getLogger(__name__)
+
+
+class ConcurrencyLimitMiddleware(BaseMiddleware):
+    """Bounds the number of message handlers running at once, rejecting the excess with a "busy" reply."""
+
+    def __init__(self, max_concurrency: int) -> None:
+        """
+        Initializes the middleware.
+
+        Args:
+            max_concurrency: Maximum number of handlers allowed to run concurrently.
+        """
+        self.max_concurrency = max_concurrency
+        self._semaphore = asyncio.Semaphore(max_concurrency)
+
+    async def __call__(
+        self,
+        handler: Callable[[TelegramObject, dict[str, Any]], Awaitable[Any]],
+        event: TelegramObject,
+        data: dict[str, Any],
+    ) -> Any:
+        if self._semaphore.locked():
+            logger.warning("Concurrency limit reached", extra={"max_concurrency": self.max_concurrency})
+            if isinstance(event, Message):
+                await event.reply(translate("telegram.error.busy", locale=get_language(event.from_user)))
+            elif isinstance(event, CallbackQuery):
+                await event.answer(translate("telegram.error.busy", locale=get_language(event.from_user)), show_alert=True)
+            return None
+
+        # The semaphore is released on exit even if the handler raises.
+        async with self._semaphore:
+            return await handler(event, data)
@@ -0,0 +1,82 @@
+import logging
+from collections.abc import Awaitable, Callable
+from typing import Any
+
+from aiogram import BaseMiddleware
+from aiogram.types import Message, TelegramObject, Update
+
+from src.cache.base import CacheProvider
+
+logger = logging.getLogger(__name__)
+
+# How long a handled message is remembered; Telegram redelivers unconfirmed updates well within this window
+DEFAULT_PROCESSED_MESSAGE_TTL_SECONDS = 600
+# Updates are remembered longer, as Telegram keeps undelivered updates for up to 24 hours, e.g. while switching modes.
+DEFAULT_PROCESSED_UPDATE_TTL_SECONDS = 86400
+
+
+class DeduplicationMiddleware(BaseMiddleware):
+    """Skips messages that were already handled, so an update redelivered by Telegram is not summarized twice."""
+
+    def __init__(self, provider: CacheProvider, ttl_seconds: int = DEFAULT_PROCESSED_MESSAGE_TTL_SECONDS) -> None:
+        """
+        Initializes the middleware.
+
+        Args:
+            provider: The cache provider that records processed messages.
+            ttl_seconds: How long a processed message is remembered.
+        """
+        self.provider = provider
+        self.ttl_seconds = ttl_seconds
+
+    async def __call__(
+        self,
+        handler: Callable[[TelegramObject, dict[str, Any]], Awaitable[Any]],
+        event: TelegramObject,
+        data: dict[str, Any],
+    ) -> Any:
+        if not isinstance(event, Message):
+            return await handler(event, data)
+
+        key = f"processed:{event.chat.id}:{event.message_id}"
+        if not await self.provider.put_if_absent(key, self.ttl_seconds):
+            logger.info("Skipping duplicate message", extra={"chat_id": event.chat.id, "message_id": event.message_id})
+            return None
+
+        return await handler(event, data)
+
+
+class UpdateDeduplicationMiddleware(BaseMiddleware):
+    """
+    Skips updates that were already handled, by ``update_id``.
+
+    Telegram redelivers updates it did not see confirmed, e.g. after a crash or when switching between
+    polling and webhook mode. Each update ID is recorded rather than only the last one, as webhook
+    updates may be handled concurrently and out of order.
+    """
+
+    def __init__(self, provider: CacheProvider, ttl_seconds: int = DEFAULT_PROCESSED_UPDATE_TTL_SECONDS) -> None:
+        """
+        Initializes the middleware.
+
+        Args:
+            provider: The cache provider that records processed updates; Valkey keeps them across restarts.
+            ttl_seconds: How long a processed update is remembered.
+        """
+        self.provider = provider
+        self.ttl_seconds = ttl_seconds
+
+    async def __call__(
+        self,
+        handler: Callable[[TelegramObject, dict[str, Any]], Awaitable[Any]],
+        event: TelegramObject,
+        data: dict[str, Any],
+    ) -> Any:
+        if not isinstance(event, Update):
+            return await handler(event, data)
+
+        if not await self.provider.put_if_absent(f"processed_update:{event.update_id}", self.ttl_seconds):
+            logger.info("Skipping duplicate update", extra={"update_id": event.update_id})
+            return None
+
+        return await handler(event, data)
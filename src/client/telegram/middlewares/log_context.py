@@ -0,0 +1,24 @@
+from collections.abc import Awaitable, Callable
+from typing import Any
+
+from aiogram import BaseMiddleware
+from aiogram.types import TelegramObject, User
+
+from src.log_context import bind_log_context
+
+
+class LogContextMiddleware(BaseMiddleware):
+    """Binds the ID of the user behind an update to every log line written while handling it."""
+
+    async def __call__(
+        self,
+        handler: Callable[[TelegramObject, dict[str, Any]], Awaitable[Any]],
+        event: TelegramObject,
+        data: dict[str, Any],
+    ) -> Any:
+        user = data.get("event_from_user")
+        if not isinstance(user, User):
+            return await handler(event, data)
+
+        with bind_log_context(userID=user.id, correlation_id=str(user.id)):
+            return await handler(event, data)
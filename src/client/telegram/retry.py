@@ -0,0 +1,82 @@
+"""
+Retry policy for Telegram API calls.
+
+Retries only errors that can succeed on a later attempt:
+- Flood control (429) waits for the ``retry_after`` Telegram asks for
+- Network and server (5xx) errors back off exponentially
+Any other API error (e.g. 400 Bad Request) is raised immediately, except that
+a message Telegram cannot parse is sent once more through a plain-text fallback.
+"""
+
+from __future__ import annotations
+
+import asyncio
+import logging
+from collections.abc import Awaitable, Callable
+from typing import TypeVar
+
+from aiogram.exceptions import TelegramBadRequest, TelegramNetworkError, TelegramRetryAfter, TelegramServerError
+
+logger = logging.getLogger(__name__)
+MAX_ATTEMPTS = 3
+BASE_DELAY_SECONDS = 1.0
+
+# Telegram's description of a 400 for text that is not valid in its parse mode
+PARSE_ERROR_DESCRIPTION = "can't parse entities"
+
+T = TypeVar("T")
+
+
+async def send_with_retry(
+    send: Callable[[], Awaitable[T]],
+    attempts: int = MAX_ATTEMPTS,
+    base_delay: float = BASE_DELAY_SECONDS,
+    plain_text_fallback: Callable[[], Awaitable[T]] | None = None,
+) -> T:
+    """
+    Call a Telegram API method, retrying transient failures.
+
+    Args:
+        send: Zero-argument callable performing the request (e.g. a ``functools.partial`` of ``message.reply``).
+        attempts: Total number of attempts.
+        base_delay: Delay before the first retry of a network/server error; doubled on each retry.
+        plain_text_fallback: Request sending the same content without a parse mode, made instead when
+            Telegram rejects the formatted text, so the content is not lost.
+
+    Returns:
+        The result of the API call.
+
+    Raises:
+        TelegramAPIError: The last error if retries are exhausted, or the first non-retryable one.
+    """
+    attempt = 1
+    while True:
+        try:
+            return await send()
+        except TelegramBadRequest as exc:
+            if plain_text_fallback is None or not is_parse_error(exc):
+                raise
+            logger.warning("Telegram could not parse formatted text, sending plain text", extra={"error": str(exc)})
+            return await send_with_retry(plain_text_fallback, attempts, base_delay)
+        except TelegramRetryAfter as exc:
+            if attempt >= attempts:
+                raise
+            delay = float(exc.retry_after)
+            error = str(exc)
+        except (TelegramNetworkError, TelegramServerError) as exc:
+            if attempt >= attempts:
+                raise
+            delay = base_delay * 2 ** (attempt - 1)
+            error = str(exc)
+
+        logger.warning(
+            "Telegram request failed, retrying",
+            extra={"attempt": attempt, "delay_seconds": delay, "error": error},
+        )
+        await asyncio.sleep(delay)
+        attempt += 1
+
+
+def is_parse_error(exc: TelegramBadRequest) -> bool:
+    """Returns whether Telegram rejected a request because its text is not valid in the parse mode."""
+    return PARSE_ERROR_DESCRIPTION in exc.message.lower()
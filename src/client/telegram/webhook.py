@@ -0,0 +1,74 @@
+from __future__ import annotations
+
+import asyncio
+import logging
+from typing import Any
+from urllib.parse import urlsplit
+
+from aiogram import Bot, Dispatcher
+from aiogram.webhook.aiohttp_server import SimpleRequestHandler, setup_application
+from aiohttp import web
+
+from src.config import Settings
+
+logger = logging.getLogger(__name__)
+
+
+def build_webhook_app(dispatcher: Dispatcher, bot: Bot, path: str, secret_token: str | None, **data: Any) -> web.Application:
+    """
+    Build the aiohttp application receiving updates posted by Telegram.
+
+    Updates are dispatched in the background, so Telegram gets its response at once and does
+    not redeliver updates whose handlers take long, e.g. summarizing a video.
+
+    Args:
+        dispatcher: Dispatcher the updates are fed to.
+        bot: Bot the updates are for.
+        path: URL path Telegram posts updates to.
+        secret_token: Token Telegram sends in the ``X-Telegram-Bot-Api-Secret-Token`` header; requests
+            without it are rejected. None accepts every request.
+        **data: Dependencies passed to the handlers, as with ``Dispatcher.start_polling``.
+
+    Returns:
+        The application, with the dispatcher's startup and shutdown hooks attached.
+    """
+    app = web.Application()
+    SimpleRequestHandler(dispatcher=dispatcher, bot=bot, secret_token=secret_token, **data).register(app, path=path)
+    setup_application(app, dispatcher, bot=bot, **data)
+    return app
+
+
+async def run_webhook(dispatcher: Dispatcher, bot: Bot, settings: Settings, **data: Any) -> None:
+    """
+    Register the webhook with Telegram and serve updates until cancelled.
+
+    Args:
+        dispatcher: Dispatcher the updates are fed to.
+        bot: Bot to register the webhook for.
+        settings: Settings with the webhook URL, secret token and listen address.
+        **data: Dependencies passed to the handlers.
+
+    Raises:
+        ValueError: If no webhook URL is configured.
+    """
+    webhook_url = settings.telegram_webhook_url
+    if not webhook_url:
+        raise ValueError("TELEGRAM_WEBHOOK_URL is not set")
+
+    app = build_webhook_app(dispatcher, bot, urlsplit(webhook_url).path or "/", settings.telegram_webhook_token, settings=settings, **data)
+    runner = web.AppRunner(app)
+    await runner.setup()
+    try:
+        await web.TCPSite(runner, settings.telegram_webhook_host, settings.telegram_webhook_port).start()
+        await bot.set_webhook(
+            webhook_url,
+            secret_token=settings.telegram_webhook_token,
+            allowed_updates=dispatcher.resolve_used_update_types(),
+        )
+        logger.info(
+            "Webhook server started",
+            extra={"host": settings.telegram_webhook_host, "port": settings.telegram_webhook_port},
+        )
+        await asyncio.Event().wait()
+    finally:
+        await runner.cleanup()
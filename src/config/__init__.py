@@ -0,0 +1,83 @@
+"""
+Application settings loaded from environment variables.
+"""
+
+from .defaults import (
+    CACHE_COMPRESSION_METHODS,
+    DEFAULT_BOT_MAX_CONCURRENCY,
+    DEFAULT_CACHE_COMPRESSION_METHOD,
+    DEFAULT_CACHE_TTL_NO_VALKEY,
+    DEFAULT_CACHE_TTL_WITH_VALKEY,
+    DEFAULT_MAX_TELEGRAM_MESSAGE_LENGTH,
+    DEFAULT_MAX_TRANSCRIPT_CHARS,
+    DEFAULT_MIN_TRANSCRIPT_CHARS,
+    DEFAULT_OPENAI_BASE_URL,
+    DEFAULT_OPENAI_MAX_RETRIES,
+    DEFAULT_OPENAI_TIMEOUT_SECONDS,
+    DEFAULT_PASTED_TEXT_MIN_LENGTH,
+    DEFAULT_PROGRESS_STYLE,
+    DEFAULT_RATE_LIMIT_WINDOW_SECONDS,
+    DEFAULT_SEND_AS_FILE_THRESHOLD,
+    DEFAULT_SUBTITLE_FORMAT,
+    DEFAULT_SUMMARY_HEADER_KEY,
+    DEFAULT_SUMMARY_STYLE,
+    DEFAULT_TELEGRAM_MODE,
+    DEFAULT_TELEGRAM_WEBHOOK_HOST,
+    DEFAULT_TELEGRAM_WEBHOOK_PORT,
+    DEFAULT_TRANSCRIPT_BACKEND,
+    DEFAULT_TRANSCRIPT_LIMIT_POLICY,
+    DEFAULT_YT_DLP_MAX_ATTEMPTS,
+    DEFAULT_YT_DLP_RETRY_DELAY_SECONDS,
+    MAX_OPENAI_TEMPERATURE,
+    MIN_OPENAI_TEMPERATURE,
+    PROGRESS_STYLES,
+    SUBTITLE_FORMATS,
+    SUMMARY_STYLES,
+    SUMMARY_STYLE_ENV_PREFIX,
+    TELEGRAM_MAX_MESSAGE_LENGTH,
+    TELEGRAM_MODES,
+    TRANSCRIPT_LIMIT_POLICIES,
+)
+from .feature_flags import FeatureFlags
+from .settings import Settings
+from .validation import ConfigError
+
+__all__ = [
+    "Settings",
+    "FeatureFlags",
+    "ConfigError",
+    "CACHE_COMPRESSION_METHODS",
+    "DEFAULT_BOT_MAX_CONCURRENCY",
+    "DEFAULT_CACHE_COMPRESSION_METHOD",
+    "DEFAULT_CACHE_TTL_NO_VALKEY",
+    "DEFAULT_CACHE_TTL_WITH_VALKEY",
+    "DEFAULT_MAX_TELEGRAM_MESSAGE_LENGTH",
+    "DEFAULT_MAX_TRANSCRIPT_CHARS",
+    "DEFAULT_MIN_TRANSCRIPT_CHARS",
+    "DEFAULT_OPENAI_BASE_URL",
+    "DEFAULT_OPENAI_MAX_RETRIES",
+    "DEFAULT_OPENAI_TIMEOUT_SECONDS",
+    "DEFAULT_PASTED_TEXT_MIN_LENGTH",
+    "DEFAULT_PROGRESS_STYLE",
+    "DEFAULT_RATE_LIMIT_WINDOW_SECONDS",
+    "DEFAULT_SEND_AS_FILE_THRESHOLD",
+    "DEFAULT_SUBTITLE_FORMAT",
+    "DEFAULT_SUMMARY_HEADER_KEY",
+    "DEFAULT_SUMMARY_STYLE",
+    "DEFAULT_TELEGRAM_MODE",
+    "DEFAULT_TELEGRAM_WEBHOOK_HOST",
+    "DEFAULT_TELEGRAM_WEBHOOK_PORT",
+    "DEFAULT_TRANSCRIPT_BACKEND",
+    "DEFAULT_TRANSCRIPT_LIMIT_POLICY",
+    "DEFAULT_YT_DLP_MAX_ATTEMPTS",
+    "DEFAULT_YT_DLP_RETRY_DELAY_SECONDS",
+    "MAX_OPENAI_TEMPERATURE",
+    "MIN_OPENAI_TEMPERATURE",
+    "PROGRESS_STYLES",
+    "SUBTITLE_FORMATS",
+    "SUMMARY_STYLES",
+    "SUMMARY_STYLE_ENV_PREFIX",
+    "TELEGRAM_MAX_MESSAGE_LENGTH",
+    "TELEGRAM_MODES",
+    "TRANSCRIPT_LIMIT_POLICIES",
+]
@@ -0,0 +1,51 @@
+"""
+Default values and allowed choices of the application settings.
+"""
+
+from __future__ import annotations
+
+from ..transform.summary_style import SummaryStyle
+from ..transform.transcript_limit import TranscriptLimitPolicy
+
+DEFAULT_OPENAI_BASE_URL = "https://api.openai.com/v1/"
+DEFAULT_OPENAI_TIMEOUT_SECONDS = 300
+DEFAULT_OPENAI_MAX_RETRIES = 3
+MIN_OPENAI_TEMPERATURE = 0.0
+MAX_OPENAI_TEMPERATURE = 2.0
+DEFAULT_CACHE_TTL_WITH_VALKEY = 86400
+DEFAULT_CACHE_TTL_NO_VALKEY = 3600
+DEFAULT_CACHE_COMPRESSION_METHOD = "gzip"
+CACHE_COMPRESSION_METHODS = ("none", "gzip", "zlib", "lzma")
+DEFAULT_RATE_LIMIT_WINDOW_SECONDS = 10
+# Telegram rejects messages longer than this; the limit is applied to the HTML actually sent.
+TELEGRAM_MAX_MESSAGE_LENGTH = 4096
+DEFAULT_MAX_TELEGRAM_MESSAGE_LENGTH = TELEGRAM_MAX_MESSAGE_LENGTH
+DEFAULT_BOT_MAX_CONCURRENCY = 5
+DEFAULT_SEND_AS_FILE_THRESHOLD = 0
+DEFAULT_PASTED_TEXT_MIN_LENGTH = 1000
+DEFAULT_YT_DLP_MAX_ATTEMPTS = 3
+DEFAULT_YT_DLP_RETRY_DELAY_SECONDS = 1.0
+DEFAULT_SUMMARY_HEADER_KEY = "telegram.response.title"
+DEFAULT_MAX_TRANSCRIPT_CHARS = 200_000
+# Shorter transcripts, e.g. a few words from a Short, are not worth an LLM request.
+DEFAULT_MIN_TRANSCRIPT_CHARS = 100
+TRANSCRIPT_LIMIT_POLICIES = tuple(policy.value for policy in TranscriptLimitPolicy)
+DEFAULT_TRANSCRIPT_LIMIT_POLICY = TranscriptLimitPolicy.TRUNCATE.value
+DEFAULT_SUMMARY_STYLE = SummaryStyle.DEFAULT.value
+# SUMMARY_STYLE_<LANG> (e.g. SUMMARY_STYLE_JA=detailed) overrides SUMMARY_STYLE for one summary language.
+SUMMARY_STYLE_ENV_PREFIX = "SUMMARY_STYLE_"
+SUMMARY_STYLES = tuple(style.value for style in SummaryStyle)
+# Subtitle formats the loader can clean, in the order they are tried after the preferred one.
+SUBTITLE_FORMATS = ("srt", "vtt", "json3")
+DEFAULT_SUBTITLE_FORMAT = "srt"
+# Transcript backends other than yt-dlp are registered at runtime, so the name is not validated here.
+DEFAULT_TRANSCRIPT_BACKEND = "yt-dlp"
+# How the processing message shows progress: each step replaces the last, or completed steps stay listed.
+PROGRESS_STYLES = ("replace", "append")
+DEFAULT_PROGRESS_STYLE = "replace"
+# How the bot receives updates: long polling, or a webhook Telegram posts updates to.
+TELEGRAM_MODES = ("polling", "webhook")
+DEFAULT_TELEGRAM_MODE = "polling"
+# The webhook server listens on all interfaces by default, so it is reachable from outside a container.
+DEFAULT_TELEGRAM_WEBHOOK_HOST = "0.0.0.0"
+DEFAULT_TELEGRAM_WEBHOOK_PORT = 8080
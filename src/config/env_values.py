@@ -0,0 +1,78 @@
+"""
+Environment variable parsing.
+
+Each loader reads one variable and falls back to a default if it is unset or malformed;
+the validation reports the values that must not be silently replaced.
+"""
+
+from __future__ import annotations
+
+import os
+from collections.abc import Callable
+from typing import TypeVar
+
+TRUE_VALUES = frozenset({"1", "true", "yes", "on"})
+FALSE_VALUES = frozenset({"0", "false", "no", "off"})
+
+N = TypeVar("N", int, float)
+
+
+def load_str(env_var: str) -> str | None:
+    """Load a string from environment, or None if it is unset or blank."""
+    return os.getenv(env_var, "").strip() or None
+
+
+def load_list(env_var: str) -> tuple[str, ...]:
+    """Load the non-empty entries of a comma-separated list from environment."""
+    return tuple(entry.strip() for entry in os.getenv(env_var, "").split(",") if entry.strip())
+
+
+def load_ids(env_var: str) -> tuple[int, ...]:
+    """Load comma-separated Telegram user or chat IDs from environment; entries that are not integers are skipped."""
+    ids = []
+    for entry in load_list(env_var):
+        try:
+            ids.append(int(entry))
+        except ValueError:
+            continue
+    return tuple(ids)
+
+
+def load_choice(env_var: str, choices: tuple[str, ...], default: str) -> str:
+    """Load a case-insensitive value from environment, falling back to default if it is not one of choices."""
+    value = os.getenv(env_var, default).strip().lower()
+    return value if value in choices else default
+
+
+def load_int(env_var: str, default: int) -> int:
+    """Load integer value from environment with fallback to default."""
+    try:
+        return int(os.getenv(env_var, str(default)))
+    except ValueError:
+        return default
+
+
+def load_float(env_var: str, default: float) -> float:
+    """Load float value from environment with fallback to default."""
+    try:
+        return float(os.getenv(env_var, str(default)))
+    except ValueError:
+        return default
+
+
+def load_optional_number(env_var: str, parse: Callable[[str], N]) -> N | None:
+    """Load a number from environment, or None if it is unset or not a number."""
+    try:
+        return parse(os.getenv(env_var, "").strip())
+    except ValueError:
+        return None
+
+
+def load_bool(env_var: str, default: bool) -> bool:
+    """Load boolean value from environment with fallback to default for unset or unrecognized values."""
+    value = os.getenv(env_var, "").strip().lower()
+    if value in TRUE_VALUES:
+        return True
+    if value in FALSE_VALUES:
+        return False
+    return default
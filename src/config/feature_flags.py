@@ -0,0 +1,38 @@
+"""
+Feature flags: toggles for optional bot behavior.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+
+from .env_values import load_bool
+
+
+@dataclass(frozen=True)
+class FeatureFlags:
+    """Toggles for optional bot behavior, loaded from ``ENABLE_*`` environment variables."""
+
+    style_keyboard: bool = True
+    link_preview: bool = True
+    merge_rolling_captions: bool = True
+    summary_cleanup: bool = True
+    summary_header: bool = True
+    chapter_summaries: bool = False
+    word_timings: bool = False
+    short_transcript_text: bool = False
+
+    @classmethod
+    def from_env(cls) -> FeatureFlags:
+        """Load feature toggles from environment; unset or unrecognized values keep the defaults."""
+        defaults = cls()
+        return cls(
+            style_keyboard=load_bool("ENABLE_STYLE_KEYBOARD", defaults.style_keyboard),
+            link_preview=load_bool("ENABLE_LINK_PREVIEW", defaults.link_preview),
+            merge_rolling_captions=load_bool("ENABLE_ROLLING_CAPTION_MERGE", defaults.merge_rolling_captions),
+            summary_cleanup=load_bool("ENABLE_SUMMARY_CLEANUP", defaults.summary_cleanup),
+            summary_header=load_bool("ENABLE_SUMMARY_HEADER", defaults.summary_header),
+            chapter_summaries=load_bool("ENABLE_CHAPTER_SUMMARIES", defaults.chapter_summaries),
+            word_timings=load_bool("ENABLE_WORD_TIMINGS", defaults.word_timings),
+            short_transcript_text=load_bool("ENABLE_SHORT_TRANSCRIPT_TEXT", defaults.short_transcript_text),
+        )
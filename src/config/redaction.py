@@ -0,0 +1,59 @@
+"""
+Masking of secrets in settings, so they can be logged.
+"""
+
+from __future__ import annotations
+
+from typing import Any
+from urllib.parse import urlsplit, urlunsplit
+
+# Fields holding credentials.
+SECRET_FIELDS = frozenset({"telegram_bot_token", "openai_api_key", "telegram_webhook_token"})
+# URL fields that may embed a password in their userinfo part.
+SECRET_URL_FIELDS = frozenset({"telegram_proxy_url", "valkey_url"})
+# Fields holding several such URLs.
+SECRET_URL_LIST_FIELDS = frozenset({"yt_dlp_proxies"})
+REDACTED = "****"
+# Shorter secrets are masked completely.
+MIN_LENGTH_TO_REVEAL = 12
+
+
+def redact_settings(values: dict[str, Any]) -> dict[str, Any]:
+    """
+    Mask the secrets in settings values.
+
+    Secrets keep only their last 4 characters and passwords embedded in URLs are masked.
+
+    Args:
+        values: Field name to value mapping of the settings.
+
+    Returns:
+        A copy of the mapping with secrets masked.
+    """
+    values = dict(values)
+    for name in SECRET_FIELDS:
+        if values[name] is not None:
+            values[name] = mask_secret(values[name])
+    for name in SECRET_URL_FIELDS:
+        if values[name]:
+            values[name] = mask_url_password(values[name])
+    for name in SECRET_URL_LIST_FIELDS:
+        values[name] = tuple(mask_url_password(url) for url in values[name])
+    return values
+
+
+def mask_secret(value: str) -> str:
+    """Mask a secret, keeping the last 4 characters of long values for identification."""
+    if len(value) < MIN_LENGTH_TO_REVEAL:
+        return REDACTED
+    return f"{REDACTED}{value[-4:]}"
+
+
+def mask_url_password(url: str) -> str:
+    """Mask the password in a URL's userinfo, if any."""
+    parts = urlsplit(url)
+    if parts.password is None:
+        return url
+    userinfo = f"{parts.username}:{REDACTED}" if parts.username else REDACTED
+    host = parts.netloc.rsplit("@", 1)[1]
+    return urlunsplit(parts._replace(netloc=f"{userinfo}@{host}"))
@@ -0,0 +1,218 @@
+"""
+Configuration module for loading application settings.
+
+Loads settings from environment variables with validation.
+Uses dataclass for immutable, type-safe configuration.
+"""
+
+from __future__ import annotations
+
+import os
+import shlex
+from dataclasses import asdict, dataclass, field
+from typing import Any
+
+from dotenv import load_dotenv
+
+from .defaults import (
+    CACHE_COMPRESSION_METHODS,
+    DEFAULT_BOT_MAX_CONCURRENCY,
+    DEFAULT_CACHE_COMPRESSION_METHOD,
+    DEFAULT_CACHE_TTL_NO_VALKEY,
+    DEFAULT_CACHE_TTL_WITH_VALKEY,
+    DEFAULT_MAX_TELEGRAM_MESSAGE_LENGTH,
+    DEFAULT_MAX_TRANSCRIPT_CHARS,
+    DEFAULT_MIN_TRANSCRIPT_CHARS,
+    DEFAULT_OPENAI_BASE_URL,
+    DEFAULT_OPENAI_MAX_RETRIES,
+    DEFAULT_OPENAI_TIMEOUT_SECONDS,
+    DEFAULT_PASTED_TEXT_MIN_LENGTH,
+    DEFAULT_PROGRESS_STYLE,
+    DEFAULT_RATE_LIMIT_WINDOW_SECONDS,
+    DEFAULT_SEND_AS_FILE_THRESHOLD,
+    DEFAULT_SUBTITLE_FORMAT,
+    DEFAULT_SUMMARY_HEADER_KEY,
+    DEFAULT_SUMMARY_STYLE,
+    DEFAULT_TELEGRAM_MODE,
+    DEFAULT_TELEGRAM_WEBHOOK_HOST,
+    DEFAULT_TELEGRAM_WEBHOOK_PORT,
+    DEFAULT_TRANSCRIPT_BACKEND,
+    DEFAULT_TRANSCRIPT_LIMIT_POLICY,
+    DEFAULT_YT_DLP_MAX_ATTEMPTS,
+    DEFAULT_YT_DLP_RETRY_DELAY_SECONDS,
+    PROGRESS_STYLES,
+    SUBTITLE_FORMATS,
+    SUMMARY_STYLES,
+    SUMMARY_STYLE_ENV_PREFIX,
+    TELEGRAM_MAX_MESSAGE_LENGTH,
+    TELEGRAM_MODES,
+    TRANSCRIPT_LIMIT_POLICIES,
+)
+from .env_values import load_bool, load_choice, load_float, load_ids, load_int, load_list, load_optional_number, load_str
+from .feature_flags import FeatureFlags
+from .redaction import redact_settings
+from .validation import validate_env_vars
+
+
+@dataclass(frozen=True)
+class Settings:
+    """
+    Application settings loaded from environment variables.
+
+    Immutable configuration class (frozen dataclass) that ensures
+    settings cannot be modified after initialization.
+    """
+
+    telegram_bot_token: str
+    telegram_proxy_url: str | None
+    openai_base_url: str
+    openai_api_key: str
+    openai_model: str
+    yt_dlp_additional_options: tuple[str, ...]
+    openai_models: tuple[str, ...] = ()
+    valkey_url: str | None = None
+    cache_summary_ttl_seconds: int = DEFAULT_CACHE_TTL_WITH_VALKEY
+    cache_transcript_ttl_seconds: int = DEFAULT_CACHE_TTL_WITH_VALKEY
+    cache_compression_method: str = DEFAULT_CACHE_COMPRESSION_METHOD
+    rate_limit_window_seconds: int = DEFAULT_RATE_LIMIT_WINDOW_SECONDS
+    max_telegram_message_length: int = DEFAULT_MAX_TELEGRAM_MESSAGE_LENGTH
+    openai_timeout_seconds: int = DEFAULT_OPENAI_TIMEOUT_SECONDS
+    openai_max_retries: int = DEFAULT_OPENAI_MAX_RETRIES
+    openai_temperature: float | None = None
+    openai_max_tokens: int | None = None
+    bot_max_concurrency: int = DEFAULT_BOT_MAX_CONCURRENCY
+    send_as_file_threshold: int = DEFAULT_SEND_AS_FILE_THRESHOLD
+    pasted_text_min_length: int = DEFAULT_PASTED_TEXT_MIN_LENGTH
+    summary_header_key: str = DEFAULT_SUMMARY_HEADER_KEY
+    max_transcript_chars: int = DEFAULT_MAX_TRANSCRIPT_CHARS
+    min_transcript_chars: int = DEFAULT_MIN_TRANSCRIPT_CHARS
+    transcript_limit_policy: str = DEFAULT_TRANSCRIPT_LIMIT_POLICY
+    summary_style: str = DEFAULT_SUMMARY_STYLE
+    summary_styles_by_language: dict[str, str] = field(default_factory=dict)
+    yt_dlp_max_attempts: int = DEFAULT_YT_DLP_MAX_ATTEMPTS
+    yt_dlp_retry_delay_seconds: float = DEFAULT_YT_DLP_RETRY_DELAY_SECONDS
+    yt_dlp_cookies_file: str | None = None
+    yt_dlp_proxies: tuple[str, ...] = ()
+    yt_dlp_allow_any_options: bool = False
+    subtitle_format: str = DEFAULT_SUBTITLE_FORMAT
+    prefer_manual_subtitles: bool = False
+    transcript_backend: str = DEFAULT_TRANSCRIPT_BACKEND
+    progress_style: str = DEFAULT_PROGRESS_STYLE
+    telegram_mode: str = DEFAULT_TELEGRAM_MODE
+    telegram_webhook_url: str | None = None
+    telegram_webhook_token: str | None = None
+    telegram_webhook_host: str = DEFAULT_TELEGRAM_WEBHOOK_HOST
+    telegram_webhook_port: int = DEFAULT_TELEGRAM_WEBHOOK_PORT
+    telegram_allowed_chat_ids: tuple[int, ...] = ()
+    telegram_allow_groups: bool = True
+    telegram_reply_not_allowed: bool = False
+    telegram_group_mention_only: bool = False
+    telegram_admin_ids: tuple[int, ...] = ()
+    feature_flags: FeatureFlags = FeatureFlags()
+
+    def redacted(self) -> dict[str, Any]:
+        """
+        Return the settings as a dict that is safe to log.
+
+        Secrets keep only their last 4 characters and passwords embedded in URLs are masked.
+
+        Returns:
+            Field name to value mapping with secrets masked.
+        """
+        return redact_settings(asdict(self))
+
+    def __repr__(self) -> str:
+        fields = ", ".join(f"{name}={value!r}" for name, value in self.redacted().items())
+        return f"{type(self).__name__}({fields})"
+
+    @classmethod
+    def from_env(cls, require_telegram: bool = True) -> Settings:
+        """
+        Load settings from environment variables.
+
+        Variables from a .env file (``ENV_FILE``, default ``.env``) are loaded first;
+        variables already set in the process environment take precedence.
+        Validates that all required environment variables are present.
+
+        Args:
+            require_telegram: Whether ``TELEGRAM_BOT_TOKEN`` is required; entry points that do not talk to Telegram pass False.
+
+        Returns:
+            Settings instance populated with environment values.
+
+        Raises:
+            ConfigError: If required environment variables are missing or invalid.
+        """
+        load_dotenv(os.getenv("ENV_FILE") or None, override=False)
+        env_vars = _load_env_vars()
+        validate_env_vars(env_vars, require_telegram)
+        return cls(**env_vars)
+
+
+def _load_env_vars() -> dict[str, Any]:
+    """Load and parse all environment variables into a dictionary."""
+    valkey_url = load_str("VALKEY_URL")
+    default_ttl = DEFAULT_CACHE_TTL_NO_VALKEY if valkey_url is None else DEFAULT_CACHE_TTL_WITH_VALKEY
+
+    return {
+        "telegram_bot_token": os.getenv("TELEGRAM_BOT_TOKEN", "").strip(),
+        "telegram_proxy_url": load_str("TELEGRAM_PROXY_URL"),
+        "openai_base_url": os.getenv("OPENAI_BASE_URL", DEFAULT_OPENAI_BASE_URL).strip(),
+        "openai_api_key": os.getenv("OPENAI_API_KEY", "").strip(),
+        "openai_model": os.getenv("OPENAI_MODEL", "").strip(),
+        "openai_models": load_list("OPENAI_MODELS"),
+        "openai_timeout_seconds": load_int("OPENAI_TIMEOUT_SECONDS", DEFAULT_OPENAI_TIMEOUT_SECONDS),
+        "openai_max_retries": load_int("OPENAI_MAX_RETRIES", DEFAULT_OPENAI_MAX_RETRIES),
+        "openai_temperature": load_optional_number("OPENAI_TEMPERATURE", float),
+        "openai_max_tokens": load_optional_number("OPENAI_MAX_TOKENS", int),
+        "valkey_url": valkey_url,
+        "cache_summary_ttl_seconds": load_int("CACHE_SUMMARY_TTL_SECONDS", default_ttl),
+        "cache_transcript_ttl_seconds": load_int("CACHE_TRANSCRIPT_TTL_SECONDS", default_ttl),
+        "cache_compression_method": load_choice("CACHE_COMPRESSION_METHOD", CACHE_COMPRESSION_METHODS, DEFAULT_CACHE_COMPRESSION_METHOD),
+        "rate_limit_window_seconds": load_int("RATE_LIMIT_WINDOW_SECONDS", DEFAULT_RATE_LIMIT_WINDOW_SECONDS),
+        "max_telegram_message_length": min(
+            max(1, load_int("MAX_TELEGRAM_MESSAGE_LENGTH", DEFAULT_MAX_TELEGRAM_MESSAGE_LENGTH)),
+            TELEGRAM_MAX_MESSAGE_LENGTH,
+        ),
+        "yt_dlp_additional_options": tuple(shlex.split(os.getenv("YT_DLP_ADDITIONAL_OPTIONS", ""))),
+        "yt_dlp_max_attempts": max(1, load_int("YT_DLP_MAX_ATTEMPTS", DEFAULT_YT_DLP_MAX_ATTEMPTS)),
+        "yt_dlp_retry_delay_seconds": max(0.0, load_float("YT_DLP_RETRY_DELAY", DEFAULT_YT_DLP_RETRY_DELAY_SECONDS)),
+        "yt_dlp_cookies_file": load_str("YT_DLP_COOKIES_FILE"),
+        "yt_dlp_proxies": load_list("YT_DLP_PROXIES"),
+        "yt_dlp_allow_any_options": load_bool("YT_DLP_ALLOW_ANY_OPTIONS", False),
+        "subtitle_format": load_choice("SUBTITLE_FORMAT", SUBTITLE_FORMATS, DEFAULT_SUBTITLE_FORMAT),
+        "prefer_manual_subtitles": load_bool("PREFER_MANUAL_SUBTITLES", False),
+        "transcript_backend": os.getenv("TRANSCRIPT_BACKEND", "").strip().lower() or DEFAULT_TRANSCRIPT_BACKEND,
+        "bot_max_concurrency": max(1, load_int("BOT_MAX_CONCURRENCY", DEFAULT_BOT_MAX_CONCURRENCY)),
+        "send_as_file_threshold": max(0, load_int("SEND_AS_FILE_THRESHOLD", DEFAULT_SEND_AS_FILE_THRESHOLD)),
+        "pasted_text_min_length": max(0, load_int("PASTED_TEXT_MIN_LENGTH", DEFAULT_PASTED_TEXT_MIN_LENGTH)),
+        "summary_header_key": load_str("SUMMARY_HEADER_KEY") or DEFAULT_SUMMARY_HEADER_KEY,
+        "max_transcript_chars": max(0, load_int("MAX_TRANSCRIPT_CHARS", DEFAULT_MAX_TRANSCRIPT_CHARS)),
+        "min_transcript_chars": max(0, load_int("MIN_TRANSCRIPT_CHARS", DEFAULT_MIN_TRANSCRIPT_CHARS)),
+        "transcript_limit_policy": load_choice("TRANSCRIPT_LIMIT_POLICY", TRANSCRIPT_LIMIT_POLICIES, DEFAULT_TRANSCRIPT_LIMIT_POLICY),
+        "summary_style": load_choice("SUMMARY_STYLE", SUMMARY_STYLES, DEFAULT_SUMMARY_STYLE),
+        "summary_styles_by_language": _load_summary_styles_by_language(),
+        "progress_style": load_choice("PROGRESS_STYLE", PROGRESS_STYLES, DEFAULT_PROGRESS_STYLE),
+        "telegram_mode": load_choice("TELEGRAM_MODE", TELEGRAM_MODES, DEFAULT_TELEGRAM_MODE),
+        "telegram_webhook_url": load_str("TELEGRAM_WEBHOOK_URL"),
+        "telegram_webhook_token": load_str("TELEGRAM_WEBHOOK_TOKEN"),
+        "telegram_webhook_host": load_str("TELEGRAM_WEBHOOK_HOST") or DEFAULT_TELEGRAM_WEBHOOK_HOST,
+        "telegram_webhook_port": load_int("TELEGRAM_WEBHOOK_PORT", DEFAULT_TELEGRAM_WEBHOOK_PORT),
+        "telegram_allowed_chat_ids": load_ids("TELEGRAM_ALLOWED_CHAT_IDS"),
+        "telegram_allow_groups": load_bool("TELEGRAM_ALLOW_GROUPS", True),
+        "telegram_reply_not_allowed": load_bool("TELEGRAM_REPLY_NOT_ALLOWED", False),
+        "telegram_group_mention_only": load_bool("TELEGRAM_GROUP_MENTION_ONLY", False),
+        "telegram_admin_ids": load_ids("TELEGRAM_ADMIN_IDS"),
+        "feature_flags": FeatureFlags.from_env(),
+    }
+
+
+def _load_summary_styles_by_language() -> dict[str, str]:
+    """Load the ``SUMMARY_STYLE_<LANG>`` overrides, keyed by lowercase language code; unknown styles are skipped."""
+    styles: dict[str, str] = {}
+    for env_var in os.environ:
+        if env_var.startswith(SUMMARY_STYLE_ENV_PREFIX) and len(env_var) > len(SUMMARY_STYLE_ENV_PREFIX):
+            style = os.environ[env_var].strip().lower()
+            if style in SUMMARY_STYLES:
+                styles[env_var[len(SUMMARY_STYLE_ENV_PREFIX) :].lower()] = style
+    return styles
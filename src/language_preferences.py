@@ -0,0 +1,77 @@
+"""
+User language preferences.
+
+Stores the summary language a user chose with /language, and the default language a group
+admin set for the whole chat with /setlang, backed by the cache provider.
+"""
+
+from __future__ import annotations
+
+from .cache import CacheProvider
+
+cache_prefix = "language:"
+chat_cache_prefix = "chat_language:"
+
+# Preferences are long-lived; with Valkey they also survive restarts.
+DEFAULT_LANGUAGE_PREFERENCE_TTL_SECONDS = 365 * 24 * 60 * 60
+
+
+class UserLanguagePreferences:
+    """Remembers the preferred output language of individual users and of group chats."""
+
+    def __init__(self, provider: CacheProvider, ttl_seconds: int = DEFAULT_LANGUAGE_PREFERENCE_TTL_SECONDS) -> None:
+        """
+        Initializes the UserLanguagePreferences.
+
+        Args:
+            provider: The cache provider for state management.
+            ttl_seconds: How long a preference is kept.
+        """
+
+        self.provider = provider
+        self.ttl_seconds = ttl_seconds
+
+    async def get(self, user_id: int) -> str | None:
+        """
+        Returns the preferred language of a user.
+
+        Args:
+            user_id: The ID of the user.
+
+        Returns:
+            The locale code, or None if the user has not chosen one.
+        """
+        return await self.provider.get(f"{cache_prefix}:{user_id}")
+
+    async def set(self, user_id: int, locale: str) -> None:
+        """
+        Stores the preferred language of a user.
+
+        Args:
+            user_id: The ID of the user.
+            locale: A supported locale code.
+        """
+        await self.provider.put(f"{cache_prefix}:{user_id}", locale, self.ttl_seconds)
+
+    async def get_chat(self, chat_id: int) -> str | None:
+        """
+        Returns the default language of a chat, which takes precedence over its members' languages.
+
+        Args:
+            chat_id: The ID of the chat.
+
+        Returns:
+            The locale code, or None if no default is set.
+        """
+        # A cleared default is stored as an empty string, as the cache has no delete.
+        return await self.provider.get(f"{chat_cache_prefix}:{chat_id}") or None
+
+    async def set_chat(self, chat_id: int, locale: str | None) -> None:
+        """
+        Stores the default language of a chat.
+
+        Args:
+            chat_id: The ID of the chat.
+            locale: A supported locale code, or None to let members use their own languages again.
+        """
+        await self.provider.put(f"{chat_cache_prefix}:{chat_id}", locale or "", self.ttl_seconds)
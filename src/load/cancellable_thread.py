@@ -0,0 +1,37 @@
+"""
+Cancellable worker threads.
+
+A thread started with ``asyncio.to_thread`` keeps running when the awaiting task is
+cancelled. The blocking function gets an event instead, set on cancellation, and is
+expected to check it and stop early.
+"""
+
+from __future__ import annotations
+
+import asyncio
+import threading
+from collections.abc import Callable
+from typing import TypeVar
+
+T = TypeVar("T")
+
+
+async def run_cancellable(func: Callable[[threading.Event], T]) -> T:
+    """
+    Run a blocking function in a worker thread, signalling it when the task is cancelled.
+
+    Args:
+        func: Blocking function, called with an event that is set once the task is cancelled.
+
+    Returns:
+        The result of ``func``.
+
+    Raises:
+        asyncio.CancelledError: If the task was cancelled; ``func`` may still be finishing in the background.
+    """
+    cancelled = threading.Event()
+    try:
+        return await asyncio.to_thread(func, cancelled)
+    except asyncio.CancelledError:
+        cancelled.set()
+        raise
@@ -0,0 +1,118 @@
+"""
+Video chapters.
+
+yt-dlp lists the chapters of many videos (title and start/end time). The raw subtitle
+cues are bucketed by chapter time range before cleaning, so every chapter gets its own
+cleaned transcript text and can be summarized separately.
+"""
+
+from __future__ import annotations
+
+import json
+import re
+from dataclasses import dataclass
+from typing import Any
+
+from .transcripts import clean_json3, clean_srt
+
+# Start time of an SRT or WebVTT cue: "00:01:23,456 --> ..." or "01:23.456 --> ..."
+_CUE_START_RE = re.compile(r"^(?:(\d{2,}):)?(\d{2}):(\d{2})[.,](\d{3}) -->")
+
+
+@dataclass(frozen=True)
+class Chapter:
+    """
+    Chapter of a video and its cleaned transcript.
+
+    Attributes:
+        title: Chapter title.
+        start: Start time in seconds.
+        end: End time in seconds, or None for the last chapter if unknown.
+        transcript: Cleaned transcript of the chapter; empty until subtitles are split.
+    """
+
+    title: str
+    start: float
+    end: float | None = None
+    transcript: str = ""
+
+
+def parse_chapters(raw_chapters: Any) -> tuple[Chapter, ...]:
+    """
+    Convert the ``chapters`` list of yt-dlp video info into chapters ordered by start time.
+
+    Args:
+        raw_chapters: Value of ``chapters`` in the yt-dlp info dict, usually a list of
+            ``{"title", "start_time", "end_time"}`` dicts or None.
+
+    Returns:
+        Chapters, or an empty tuple if the video has none or they are malformed.
+    """
+    if not isinstance(raw_chapters, list):
+        return ()
+    chapters: list[Chapter] = []
+    for raw in raw_chapters:
+        if not isinstance(raw, dict) or raw.get("start_time") is None:
+            continue
+        end = raw.get("end_time")
+        chapters.append(Chapter(str(raw.get("title") or "").strip(), float(raw["start_time"]), float(end) if end is not None else None))
+    return tuple(sorted(chapters, key=lambda chapter: chapter.start))
+
+
+def split_transcript_by_chapters(raw_subtitles: str, is_json3: bool, chapters: tuple[Chapter, ...], merge_rolling: bool) -> tuple[Chapter, ...]:
+    """
+    Fill in the transcript of each chapter from raw subtitles.
+
+    Each cue goes to the chapter its start time falls in; cues before the first chapter
+    go to the first one. Every chapter's cues are then cleaned like a whole transcript.
+
+    Args:
+        raw_subtitles: Raw SRT, WebVTT or json3 subtitle content.
+        is_json3: Whether the content is json3 rather than SRT or WebVTT.
+        chapters: Chapters ordered by start time.
+        merge_rolling: Collapse words repeated across adjacent lines (see clean_srt).
+
+    Returns:
+        The chapters with their transcripts set.
+    """
+    if not chapters:
+        return ()
+    clean = clean_json3 if is_json3 else clean_srt
+    buckets = _bucket_json3_events(raw_subtitles, chapters) if is_json3 else _bucket_cue_lines(raw_subtitles, chapters)
+    return tuple(
+        Chapter(chapter.title, chapter.start, chapter.end, clean(text, merge_rolling=merge_rolling))
+        for chapter, text in zip(chapters, buckets, strict=True)
+    )
+
+
+def _chapter_index(chapters: tuple[Chapter, ...], seconds: float) -> int:
+    """Return the index of the last chapter starting at or before ``seconds``, or 0."""
+    index = 0
+    for i, chapter in enumerate(chapters):
+        if chapter.start <= seconds:
+            index = i
+    return index
+
+
+def _bucket_cue_lines(raw_subtitles: str, chapters: tuple[Chapter, ...]) -> list[str]:
+    """Group SRT/WebVTT lines by the chapter of the cue they belong to, returning the subtitles of each chapter."""
+    buckets: list[list[str]] = [[] for _ in chapters]
+    index = 0
+    for line in raw_subtitles.splitlines():
+        if match := _CUE_START_RE.match(line.strip()):
+            hours, minutes, seconds, millis = match.groups()
+            index = _chapter_index(chapters, int(hours or 0) * 3600 + int(minutes) * 60 + int(seconds) + int(millis) / 1000)
+        buckets[index].append(line)
+    return ["\n".join(lines) for lines in buckets]
+
+
+def _bucket_json3_events(raw_subtitles: str, chapters: tuple[Chapter, ...]) -> list[str]:
+    """Group json3 events by the chapter of their start time, returning the subtitles of each chapter."""
+    buckets: list[list[Any]] = [[] for _ in chapters]
+    try:
+        events = json.loads(raw_subtitles).get("events") or []
+    except (ValueError, AttributeError):
+        events = []
+    for event in events:
+        buckets[_chapter_index(chapters, float(event.get("tStartMs", 0)) / 1000)].append(event)
+    return [json.dumps({"events": bucket}) for bucket in buckets]
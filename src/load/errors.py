@@ -0,0 +1,29 @@
+"""
+Errors raised when the transcript of a video cannot be loaded.
+
+Each cause users can act on has its own type, so clients can tell them why: an invalid
+link, a private or unavailable video, or a video without subtitles. Errors replacing a
+built-in type (ValueError, FileNotFoundError) keep it as a base for existing callers.
+"""
+
+from __future__ import annotations
+
+
+class VideoLoadError(RuntimeError):
+    """Raised when the transcript of a video cannot be loaded."""
+
+
+class InvalidVideoUrlError(VideoLoadError, ValueError):
+    """Raised when a URL does not point to a video of a supported platform."""
+
+
+class VideoPrivateError(VideoLoadError):
+    """Raised when a video is private."""
+
+
+class VideoUnavailableError(VideoLoadError):
+    """Raised when a video was removed, is blocked in the server's region or needs an account to watch."""
+
+
+class NoSubtitlesError(VideoLoadError, FileNotFoundError):
+    """Raised when a video has no subtitles or automatic captions to build a transcript from."""
@@ -0,0 +1,60 @@
+"""
+Transcript backend interface.
+
+A transcript backend fetches the raw subtitles of a video in a given language. The
+loader cleans them and splits them by chapter, whichever backend fetched them.
+"""
+
+from __future__ import annotations
+
+import threading
+from abc import ABC, abstractmethod
+from dataclasses import dataclass
+
+
+class FetchCancelledError(Exception):
+    """Raised when a fetch is abandoned because the request that needed it was cancelled."""
+
+
+@dataclass(frozen=True)
+class RawSubtitles:
+    """
+    Subtitles as fetched, before cleaning.
+
+    Attributes:
+        content: Raw subtitle content.
+        format: Subtitle format: ``srt``, ``vtt`` or ``json3``.
+    """
+
+    content: str
+    format: str
+
+
+class TranscriptBackend(ABC):
+    """Abstract base class for transcript backends (yt-dlp, timedtext API, third-party services)."""
+
+    @abstractmethod
+    def fetch(self, url: str, video_id: str, language: str, has_manual_subtitles: bool, cancelled: threading.Event) -> RawSubtitles | None:
+        """
+        Fetch the subtitles of a video.
+
+        Called from a worker thread, so implementations may block. The thread cannot be
+        interrupted when the request is cancelled; implementations should check ``cancelled``
+        while they work, stop early and leave no temporary files behind.
+
+        Args:
+            url: Canonical video URL.
+            video_id: Video ID.
+            language: Subtitle language code.
+            has_manual_subtitles: Whether the video lists manual subtitles in ``language``;
+                backends that tell them from auto-generated captions may prefer them.
+            cancelled: Set once the request is cancelled and the result is no longer needed.
+
+        Returns:
+            The subtitles, or None if the video has none in ``language``.
+
+        Raises:
+            RuntimeError: If the subtitles could not be fetched.
+            FetchCancelledError: If the fetch stopped because ``cancelled`` was set.
+        """
+        pass
@@ -0,0 +1,49 @@
+"""
+Transcript backend registry.
+
+Backends are registered by name and chosen with ``TRANSCRIPT_BACKEND``. yt-dlp is
+registered by default; deployments that cannot run it register their own backend
+before the loader is created.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Callable
+
+from ..config import DEFAULT_TRANSCRIPT_BACKEND, Settings
+from .transcript_backend import TranscriptBackend
+from .yt_dlp_backend import YtDlpTranscriptBackend
+
+TranscriptBackendFactory = Callable[[Settings], TranscriptBackend]
+
+_factories: dict[str, TranscriptBackendFactory] = {DEFAULT_TRANSCRIPT_BACKEND: YtDlpTranscriptBackend}
+
+
+def register_transcript_backend(name: str, factory: TranscriptBackendFactory) -> None:
+    """
+    Register a transcript backend, replacing any backend registered under the same name.
+
+    Args:
+        name: Backend name, matched case-insensitively against ``TRANSCRIPT_BACKEND``.
+        factory: Builds the backend from the application settings.
+    """
+    _factories[name.strip().lower()] = factory
+
+
+def get_transcript_backend(settings: Settings) -> TranscriptBackend:
+    """
+    Build the transcript backend selected by the settings.
+
+    Args:
+        settings: Application settings with the backend name.
+
+    Returns:
+        Transcript backend instance.
+
+    Raises:
+        ValueError: If no backend is registered under the configured name.
+    """
+    factory = _factories.get(settings.transcript_backend)
+    if factory is None:
+        raise ValueError(f"unknown transcript backend: {settings.transcript_backend}")
+    return factory(settings)
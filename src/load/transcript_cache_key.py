@@ -0,0 +1,41 @@
+"""
+Cache keys of cleaned video transcripts.
+
+Only cleaned text is cached, so the key carries a fingerprint of everything that
+changes the cleaned output: the cleaning code version, the cleaning options and the
+options choosing the subtitles (manual or automatic, and the transcript backend).
+Changing any of them misses the cache instead of returning a different transcript.
+"""
+
+from __future__ import annotations
+
+import hashlib
+
+from ..config import Settings
+from .transcripts import cleaning_version
+
+cache_prefix = "transcript:"
+
+
+def transcript_cache_key(url: str, settings: Settings) -> str:
+    """
+    Return the cache key of the cleaned transcript of a video.
+
+    Args:
+        url: Canonical video URL.
+        settings: Application settings with the cleaning and subtitle selection options.
+
+    Returns:
+        Cache key for the transcript.
+    """
+    flags = settings.feature_flags
+    options = (
+        f"rolling{int(flags.merge_rolling_captions)}:{settings.subtitle_format}:words{int(flags.word_timings)}"
+        f":manual{int(settings.prefer_manual_subtitles)}:{settings.transcript_backend}"
+    )
+    return f"{cache_prefix}:{_video_hash(url)}:c{cleaning_version}:{options}"
+
+
+def _video_hash(url: str) -> str:
+    """Return the hash identifying a video URL in the cache."""
+    return hashlib.sha256(url.encode("utf-8")).hexdigest()
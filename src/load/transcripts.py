@@ -1,15 +1,21 @@
 """
-SRT and WebVTT subtitle cleaning utilities.
+SRT, WebVTT and json3 subtitle cleaning utilities.
 
-Provides functions to parse and clean both SRT (SubRip Subtitle) and WebVTT
-formats, removing timestamps, sequence numbers, WebVTT headers, inline tags,
+Provides functions to parse and clean SRT (SubRip Subtitle), WebVTT and YouTube
+json3 formats, removing timestamps, sequence numbers, WebVTT headers, inline tags,
 and duplicate lines to produce clean transcript text.
 """
 
 from __future__ import annotations
 
+import html
+import json
 import re
 
+# Bump when the cleaned output changes so transcripts cleaned by the old code are not reused from the cache.
+# 2: transcripts carry per-chapter text.
+cleaning_version = 2
+
 _TIMELINE_RE = re.compile(r"^(?:\d{2}:)?\d{2}:\d{2}[.,]\d{3} --> (?:\d{2}:)?\d{2}:\d{2}[.,]\d{3}.*$")
 """
 Regular expression to match SRT and VTT timestamp lines.
@@ -57,8 +63,47 @@ Regular expression to match HTML-like tags inside subtitles.
 Matches patterns like <b>, <i>, <c>, or VTT per-word timestamp tags like <00:00:00.000>.
 """
 
+_MIN_ROLLING_OVERLAP_WORDS = 2
+"""
+Fewest words a caption line must repeat from the end of the previous one to be a rolling overlap.
+
+A single repeated word ("...said that", "that was...") is usually real speech, so it only
+counts when it is the whole previous line.
+"""
+
+_INVISIBLE_RE = re.compile(r"[\x00-\x08\x0e-\x1b\x7f-\x84\x86-\x9f\u200b-\u200d\u2060\ufeff]")
+"""
+Regular expression to match control characters and zero-width characters.
+
+Matches C0/C1 control characters (except those Python treats as whitespace, which
+are collapsed instead), zero-width space/joiners, word joiner, and the byte order mark.
+"""
+
+_WHITESPACE_RE = re.compile(r"\s+")
+"""
+Regular expression to match runs of whitespace, including non-breaking spaces.
+"""
+
 
-def clean_srt(text: str) -> str:
+def normalize_text(text: str) -> str:
+    """
+    Normalize transcript text.
+
+    Unescapes HTML entities (&amp;, &#39;), removes control and zero-width
+    characters, collapses runs of whitespace into a single space, and trims.
+
+    Args:
+        text: Transcript text.
+
+    Returns:
+        Normalized text.
+    """
+    text = html.unescape(text)
+    text = _INVISIBLE_RE.sub("", text)
+    return _WHITESPACE_RE.sub(" ", text).strip()
+
+
+def clean_srt(text: str, merge_rolling: bool = False) -> str:
     """
     Clean SRT or WebVTT subtitle text by removing formatting artifacts.
 
@@ -69,9 +114,13 @@ def clean_srt(text: str) -> str:
     - Inline HTML-like tags (<b>, <i>, <c>, <00:00:00.000>)
     - Special SRT control characters (\\h, \\n, etc.)
     - Duplicate lines
+    - Rolling overlaps between adjacent lines, if ``merge_rolling`` is set
+    - HTML entities, zero-width characters, and repeated whitespace (see normalize_text)
 
     Args:
         text: Raw SRT or WebVTT subtitle content.
+        merge_rolling: Collapse words repeated across adjacent lines. Meant for
+            auto-generated captions; manually authored subtitles should keep it off.
 
     Returns:
         Cleaned transcript as continuous text with duplicates removed.
@@ -100,4 +149,71 @@ def clean_srt(text: str) -> str:
         seen.add(line)
         chunks.append(line)
 
-    return " ".join(chunks).strip()
+    return _join_lines(chunks, merge_rolling)
+
+
+def clean_json3(text: str, merge_rolling: bool = False) -> str:
+    """
+    Clean YouTube json3 subtitles into transcript text.
+
+    Each event's segments are joined into caption lines; duplicate lines are dropped
+    and rolling overlaps merged like in clean_srt.
+
+    Args:
+        text: Raw json3 subtitle content.
+        merge_rolling: Collapse words repeated across adjacent lines.
+
+    Returns:
+        Cleaned transcript as continuous text, or an empty string if the content is not valid json3.
+    """
+    try:
+        events = json.loads(text).get("events") or []
+    except (ValueError, AttributeError):
+        return ""
+
+    seen: set[str] = set()
+    chunks: list[str] = []
+    for event in events:
+        caption = "".join(str(segment.get("utf8", "")) for segment in event.get("segs") or [])
+        for raw_line in caption.splitlines():
+            line = raw_line.strip()
+            if line and line not in seen:
+                seen.add(line)
+                chunks.append(line)
+
+    return _join_lines(chunks, merge_rolling)
+
+
+def _join_lines(lines: list[str], merge_rolling: bool) -> str:
+    """Join cleaned caption lines into normalized text, merging rolling overlaps if requested."""
+    if merge_rolling:
+        return normalize_text(_merge_rolling_lines(lines))
+    return normalize_text(" ".join(lines))
+
+
+def _merge_rolling_lines(lines: list[str]) -> str:
+    """
+    Join caption lines, dropping the words each line repeats from the end of the text so far.
+
+    Auto-generated captions roll: "the quick", "the quick brown", "quick brown fox"
+    are merged into "the quick brown fox".
+    """
+    words: list[str] = []
+    previous_line_length = 0
+    for line in lines:
+        line_words = line.split()
+        words.extend(line_words[_overlap_length(words, line_words, previous_line_length) :])
+        previous_line_length = len(line_words)
+    return " ".join(words)
+
+
+def _overlap_length(previous: list[str], current: list[str], previous_line_length: int) -> int:
+    """
+    Return the largest k such that the last k words of previous equal the first k words of current.
+
+    Overlaps shorter than ``_MIN_ROLLING_OVERLAP_WORDS`` count only if they cover the whole previous line.
+    """
+    for k in range(min(len(previous), len(current)), 0, -1):
+        if previous[-k:] == current[:k] and (k >= _MIN_ROLLING_OVERLAP_WORDS or k >= previous_line_length):
+            return k
+    return 0
@@ -0,0 +1,86 @@
+"""
+Video URL extraction module.
+
+Finds the links of every supported video provider in free text, such as a chat
+message or CLI input, and canonicalizes them.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+
+from .video_provider import PROVIDERS, RegexProvider
+
+
+@dataclass(frozen=True)
+class ProviderMatch:
+    """
+    Video URL found in text.
+
+    Attributes:
+        provider: Provider the URL belongs to.
+        url: Canonical URL of the video.
+        video_id: Video ID.
+        position: Offset of the URL in the text.
+    """
+
+    provider: RegexProvider
+    url: str
+    video_id: str
+    position: int
+
+
+def extract_urls(text: str) -> list[str]:
+    """
+    Extract video URLs from text using all supported providers.
+
+    Returns URLs from the first matching provider (priority order:
+    YouTube standard, YouTube Shorts, VK Video).
+
+    Args:
+        text: Text to search for video URLs.
+
+    Returns:
+        List of extracted URLs (empty if none found).
+    """
+    for provider in PROVIDERS:
+        if provider.is_valid_url(text):
+            return provider.extract_urls(text)
+    return []
+
+
+def extract_all_urls(text: str) -> list[ProviderMatch]:
+    """
+    Extract video URLs of every supported provider from text.
+
+    Different links to the same video (``youtu.be/ID`` and ``youtube.com/watch?v=ID``)
+    are returned once, at the position they first appear.
+
+    Args:
+        text: Text to search for video URLs.
+
+    Returns:
+        Matches ordered by position in the text (empty if none found).
+    """
+    found = sorted(
+        ((match.start(), provider, match.group(0)) for provider in PROVIDERS for match in provider.pattern.finditer(text)),
+        key=lambda item: item[0],
+    )
+    matches: dict[str, ProviderMatch] = {}
+    for position, provider, url in found:
+        canonical_url, video_id = provider.canonicalize(url)
+        matches.setdefault(canonical_url, ProviderMatch(provider, canonical_url, video_id, position))
+    return list(matches.values())
+
+
+def extract_canonical_urls(text: str) -> list[str]:
+    """
+    Extract the canonical URLs of the videos linked in text, from every supported provider.
+
+    Args:
+        text: Text to search for video URLs.
+
+    Returns:
+        Canonical URLs without duplicates, in the order they appear (empty if none found).
+    """
+    return [match.url for match in extract_all_urls(text)]
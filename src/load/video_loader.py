@@ -3,50 +3,37 @@ Video loader module for downloading and extracting video transcripts.
 
 This module provides functionality to:
 - Extract video information from URLs (YouTube, VK Video)
-- Download subtitles/transcripts using yt-dlp
-- Clean and process SRT subtitle format
+- Fetch subtitles with a transcript backend (yt-dlp by default)
+- Clean and process SRT, WebVTT and json3 subtitles
 """
 
 from __future__ import annotations
 
 import asyncio
-import hashlib
 import logging
-import tempfile
+import threading
 from dataclasses import asdict, dataclass
-from pathlib import Path
+from functools import partial
 from typing import Any
 
 import yt_dlp
 
 from ..cache import CacheProvider, get_cache_provider
 from ..config import Settings
-from .transcripts import clean_srt
-from .video_provider import build_video_source
-from .yt_dlp_logger import YtDlpCaptureLogger
+from .cancellable_thread import run_cancellable
+from .chapters import Chapter, parse_chapters, split_transcript_by_chapters
+from .errors import NoSubtitlesError
+from .transcript_backend import FetchCancelledError, TranscriptBackend
+from .transcript_backend_factory import get_transcript_backend
+from .transcript_cache_key import transcript_cache_key
+from .transcripts import clean_json3, clean_srt
+from .video_provider import build_video_source, find_provider
+from .word_timings import WordTiming, parse_json3_words
+from .yt_dlp_options import build_ydl_opts
+from .yt_dlp_proxies import ProxyRotator
+from .yt_dlp_retry import call_with_retry
 
 logger = logging.getLogger(__name__)
-max_attempts = 3
-cache_prefix = "transcript:"
-
-
-def _is_safe_option_value(value: str) -> bool:
-    """
-    Check if an option value is safe (no shell injection or path traversal).
-
-    Args:
-        value: The option value to validate.
-
-    Returns:
-        True if safe, False otherwise.
-    """
-    # Block path traversal
-    if ".." in value or value.startswith("/"):
-        return False
-    # Block shell metacharacters
-    if any(char in value for char in (";", "|", "&", "$", "`", "(", ")", "<", ">", "\\", "\n", "\r")):
-        return False
-    return True
 
 
 @dataclass(frozen=True)
@@ -61,6 +48,8 @@ class VideoInfo:
         title: Video title.
         thumbnail: URL to video thumbnail.
         subtitles: Dictionary of available subtitle tracks.
+        duration: Video length in seconds, if known.
+        chapters: Chapters of the video, if it has any.
     """
 
     id: str
@@ -69,6 +58,8 @@ class VideoInfo:
     title: str
     thumbnail: str
     subtitles: dict[str, list[dict[str, str]]]
+    duration: int | None = None
+    chapters: tuple[Chapter, ...] = ()
 
 
 @dataclass(frozen=True)
@@ -83,6 +74,9 @@ class VideoTranscript:
         title: Video title.
         thumbnail: URL to video thumbnail.
         transcript: Cleaned transcript text.
+        duration: Video length in seconds, if known.
+        chapters: Chapters of the video with their own cleaned transcripts, if it has any.
+        words: Word-level timings, if enabled and json3 subtitles were available.
     """
 
     id: str
@@ -91,14 +85,24 @@ class VideoTranscript:
     title: str
     thumbnail: str
     transcript: str
+    duration: int | None = None
+    chapters: tuple[Chapter, ...] = ()
+    words: tuple[WordTiming, ...] = ()
+
+    @classmethod
+    def from_dict(cls, data: dict[str, Any]) -> VideoTranscript:
+        """Rebuild a transcript from its ``dataclasses.asdict`` form, e.g. as read back from the cache."""
+        chapters = tuple(Chapter(**chapter) for chapter in data.get("chapters") or ())
+        words = tuple(WordTiming(**word) for word in data.get("words") or ())
+        return cls(**{**data, "chapters": chapters, "words": words})
 
 
 class VideoDataLoader:
     """
     Loads video information and transcripts from supported platforms.
 
-    Supports YouTube and VK Video platforms. Handles retries,
-    temporary file management, and subtitle cleaning.
+    Supports YouTube and VK Video platforms. Handles retries and subtitle
+    cleaning; subtitles are fetched by the configured transcript backend.
     """
 
     def __init__(self, settings: Settings) -> None:
@@ -106,151 +110,113 @@ class VideoDataLoader:
         Initialize the video loader.
 
         Args:
-            settings: Application settings with cache and yt-dlp configuration.
+            settings: Application settings with cache, yt-dlp and transcript backend configuration.
+
+        Raises:
+            ValueError: If the configured transcript backend is not registered.
         """
         self.settings = settings
         self.cache_provider: CacheProvider = get_cache_provider(settings)
         self.yt_dlp_additional_options = settings.yt_dlp_additional_options
+        self.proxy_rotator = ProxyRotator(settings.yt_dlp_proxies)
+        self.transcript_backend: TranscriptBackend = get_transcript_backend(settings)
 
-    async def load(self, url: str) -> VideoTranscript:
+    async def load(self, url: str, refresh: bool = False) -> VideoTranscript:
         """
         Load transcript.
 
         Args:
             url: Video URL to process.
+            refresh: Download the transcript again even if it is cached, and replace the cached one,
+                e.g. after the video was re-uploaded or its premiere ended.
 
         Returns:
             VideoTranscript if available, otherwise raise exception.
 
         Throws:
-            - `RuntimeError` - video info/subtitles failed
-            - `FileNotFoundError` - no subtitles
-            - `ValueError` - URL is not valid
+            - `InvalidVideoUrlError` - URL is not valid
+            - `VideoPrivateError` - video is private
+            - `VideoUnavailableError` - video was removed or cannot be watched
+            - `NoSubtitlesError` - no subtitles
+            - `RuntimeError` - video info/subtitles failed otherwise
             - `OSError` - failed to clean up temporary files
         """
         url, video_id = build_video_source(url)
-        cache_key = f"{cache_prefix}:{self._get_video_hash(url)}"
-        cached_transcript = await self.cache_provider.get_dict(cache_key)
+        cache_key = transcript_cache_key(url, self.settings)
+        cached_transcript = None if refresh else await self.cache_provider.get_dict(cache_key)
         if cached_transcript:
-            transcript = VideoTranscript(**cached_transcript)
+            transcript = VideoTranscript.from_dict(cached_transcript)
             logger.debug("Transcript loaded from cache", extra={"url": url})
             return transcript
 
-        transcript = await asyncio.to_thread(self._load, url, video_id)
-
-        await self.cache_provider.put_dict(
-            cache_key,
-            asdict(transcript),
-            self.settings.cache_transcript_ttl_seconds,
-        )
+        try:
+            transcript = await run_cancellable(partial(self._load, url, video_id))
+        except asyncio.CancelledError:
+            logger.info("Transcript loading cancelled", extra={"url": url})
+            raise
 
+        await self.cache_provider.put_dict(cache_key, asdict(transcript), self.settings.cache_transcript_ttl_seconds)
         return transcript
 
-    def _load(self, url: str, video_id: str) -> VideoTranscript:
+    def _load(self, url: str, video_id: str, cancelled: threading.Event | None = None) -> VideoTranscript:
         """
         Load video info and download transcript.
 
         Performs the following steps:
-        1. Extract video metadata with retries
+        1. Extract video metadata, retrying transient failures
         2. Detect subtitle language
-        3. Download subtitles with retries
-        4. Clean and process SRT content
-        5. Cleanup temporary files
+        3. Fetch subtitles with the configured transcript backend
+        4. Clean the subtitles and split them by chapter
+
+        Args:
+            url: Canonical video URL.
+            video_id: Video ID.
+            cancelled: Set once the request is cancelled; the subtitles are then not fetched.
 
         Raises:
-            RuntimeError: If video info or subtitles cannot be loaded after retries.
-            FileNotFoundError: If no subtitles are available.
+            VideoPrivateError: If the video is private.
+            VideoUnavailableError: If the video was removed or cannot be watched.
+            NoSubtitlesError: If no subtitles are available.
+            RuntimeError: If video info or subtitles cannot be loaded otherwise.
+            FetchCancelledError: If ``cancelled`` was set before the subtitles were fetched.
         """
+        cancelled = cancelled or threading.Event()
+        provider = find_provider(url)
         logger.info(
             "Loading video info",
             extra={
                 "url": url,
                 "video_id": video_id,
+                "provider": provider.name if provider else None,
             },
         )
 
-        # Extract video info with retries
-        last_error: Exception | None = None
-        for attempt in range(max_attempts):
-            try:
-                ydl_opts = self._build_ydl_opts(
-                    {
-                        "dumpjson": True,
-                    }
-                )
-                with yt_dlp.YoutubeDL(ydl_opts) as ydl:
-                    raw_info = ydl.extract_info(url, download=False)
-                    info = VideoInfo(
-                        id=str(raw_info.get("id", "")),
-                        language=str(raw_info.get("language", "") or ""),
-                        uploader=str(raw_info.get("uploader", "") or ""),
-                        title=str(raw_info.get("title", "") or ""),
-                        thumbnail=str(raw_info.get("thumbnail", "") or ""),
-                        subtitles=dict(raw_info.get("subtitles", {}) or {}),
-                    )
-                break
-            except Exception as exc:
-                last_error = exc
-                logger.warning(
-                    "Failed to load video info",
-                    extra={"attempt": attempt + 1, "url": url, "error": str(exc)},
-                )
-        else:
-            raise RuntimeError(f"Failed to load video info after {max_attempts} attempts: {last_error}")
+        info = call_with_retry(
+            partial(self._extract_info, url),
+            "load video info",
+            self.settings.yt_dlp_max_attempts,
+            self.settings.yt_dlp_retry_delay_seconds,
+            lambda: {"url": url},
+        )
 
         language = self._detect_language(info)
         logger.debug("Detected transcript language", extra={"url": url, "language": language})
 
-        # Download subtitles with retries
-        ydl_logger = YtDlpCaptureLogger()
-        for attempt in range(max_attempts):
-            try:
-                ydl_logger.messages.clear()
-                ydl_opts = self._build_ydl_opts(
-                    {
-                        "no_progress": True,
-                        "skip_download": True,
-                        "writesubtitles": True,
-                        "writeautomaticsub": True,
-                        "subtitleslangs": [language, f"{language}_auto", "-live_chat"],
-                        "subtitlesformat": "srt/vtt/best",
-                        "outtmpl": self._get_subtitle_template_path(video_id),
-                        "logger": ydl_logger,
-                        "quiet": False,
-                        "no_warnings": False,
-                    }
-                )
-                with yt_dlp.YoutubeDL(ydl_opts) as ydl:
-                    ydl.extract_info(url, download=True)
-                break
-            except Exception as exc:
-                last_error = exc
-                logger.warning(
-                    "Failed to download subtitles",
-                    extra={
-                        "attempt": attempt + 1,
-                        "url": url,
-                        "error": str(exc),
-                        "yt_dlp_output": "\n".join(ydl_logger.messages),
-                    },
-                )
-        else:
-            raise RuntimeError(f"Failed to download subtitles after {max_attempts} attempts: {last_error}")
-
-        subtitle_file = self._find_subtitle_file(video_id, language)
-        if subtitle_file is None:
-            logger.warning(
-                "No subtitles found",
-                extra={
-                    "url": url,
-                    "language": language,
-                    "yt_dlp_output": "\n".join(ydl_logger.messages),
-                },
-            )
-            raise FileNotFoundError("no subtitles found")
-
-        raw_transcript = subtitle_file.read_text(encoding="utf-8", errors="ignore")
-        transcript_text = clean_srt(raw_transcript)
+        if cancelled.is_set():
+            raise FetchCancelledError(f"transcript loading cancelled: {url}")
+        has_manual_subtitles = self._has_manual_subtitles(info, language)
+        subtitles = self.transcript_backend.fetch(url, video_id, language, has_manual_subtitles=has_manual_subtitles, cancelled=cancelled)
+        if subtitles is None:
+            logger.warning("No subtitles found", extra={"url": url, "language": language, "backend": self.settings.transcript_backend})
+            raise NoSubtitlesError("no subtitles found")
+
+        # Only auto-generated captions roll; manual subtitles are listed in info.subtitles.
+        merge_rolling = self.settings.feature_flags.merge_rolling_captions and not has_manual_subtitles
+        is_json3 = subtitles.format == "json3"
+        cleaner = clean_json3 if is_json3 else clean_srt
+        transcript_text = cleaner(subtitles.content, merge_rolling=merge_rolling)
+        chapters = split_transcript_by_chapters(subtitles.content, is_json3, info.chapters, merge_rolling)
+        words = parse_json3_words(subtitles.content) if is_json3 and self.settings.feature_flags.word_timings else ()
 
         transcript = VideoTranscript(
             id=info.id,
@@ -259,71 +225,44 @@ class VideoDataLoader:
             title=info.title,
             thumbnail=info.thumbnail,
             transcript=transcript_text,
+            duration=info.duration,
+            chapters=chapters,
+            words=words,
         )
 
         logger.info("Transcript loaded successfully", extra={"url": url, "length": len(transcript_text)})
 
-        self._cleanup_subtitle_files(video_id)
-
         return transcript
 
-    def _build_ydl_opts(self, extra_options: dict[str, Any] | None = None) -> dict[str, Any]:
-        """
-        Build yt-dlp options with additional user options.
-
-        Args:
-            extra_options: Additional options to merge with defaults.
+    def _extract_info(self, url: str) -> VideoInfo:
+        """Extract video metadata without downloading anything."""
+        with yt_dlp.YoutubeDL(self._build_ydl_opts({"dumpjson": True})) as ydl:
+            raw_info = ydl.extract_info(url, download=False)
+        return VideoInfo(
+            id=str(raw_info.get("id", "")),
+            language=str(raw_info.get("language", "") or ""),
+            uploader=str(raw_info.get("uploader", "") or ""),
+            title=str(raw_info.get("title", "") or ""),
+            thumbnail=str(raw_info.get("thumbnail", "") or ""),
+            subtitles=dict(raw_info.get("subtitles", {}) or {}),
+            duration=int(raw_info["duration"]) if raw_info.get("duration") else None,
+            chapters=parse_chapters(raw_info.get("chapters")),
+        )
 
-        Returns:
-            Dictionary of yt-dlp options.
+    def _build_ydl_opts(self, extra_options: dict[str, Any] | None = None) -> dict[str, Any]:
+        """Build yt-dlp options from the defaults, ``extra_options`` and the configured user options, using the next proxy."""
+        return build_ydl_opts(
+            self.yt_dlp_additional_options,
+            extra_options,
+            cookies_file=self.settings.yt_dlp_cookies_file,
+            proxy=self.proxy_rotator.next(),
+            allow_any_options=self.settings.yt_dlp_allow_any_options,
+        )
 
-        Note:
-            User-provided options are filtered to prevent injection attacks.
-            Only safe options (starting with --) are allowed.
-        """
-        opts: dict[str, Any] = {
-            "quiet": True,
-            "no_warnings": True,
-            "extract_flat": False,
-        }
-        if extra_options:
-            opts.update(extra_options)
-        # Add user-provided options as keyword arguments
-        i = 0
-        while i < len(self.yt_dlp_additional_options):
-            opt = self.yt_dlp_additional_options[i]
-            if opt.startswith("--"):
-                key = opt[2:].replace("-", "_")
-                # Check if next option is a value (not another flag)
-                if i + 1 < len(self.yt_dlp_additional_options) and not self.yt_dlp_additional_options[i + 1].startswith("--"):
-                    value = self.yt_dlp_additional_options[i + 1]
-                    # Validate value to prevent injection attacks
-                    if not _is_safe_option_value(value):
-                        logger.warning(
-                            "Skipping unsafe yt-dlp option value",
-                            extra={"key": key, "value_length": len(value)},
-                        )
-                        i += 2
-                        continue
-                    opts[key] = value
-                    i += 1
-                else:
-                    opts[key] = True
-            i += 1
-        return opts
-
-    def _get_video_hash(self, url: str) -> str:
-        """Return the cache key for this video URL."""
-        return hashlib.sha256(url.encode("utf-8")).hexdigest()
-
-    def _get_subtitle_template_path(self, video_id: str) -> str:
-        """Generate template path for subtitle files in temp directory."""
-        temp_dir = Path(tempfile.gettempdir())
-        return str(temp_dir / f"subtitles_{video_id}.%(ext)s")
-
-    def _get_subtitle_prefix(self, video_id: str) -> Path:
-        """Get prefix for subtitle file names in temp directory."""
-        return Path(tempfile.gettempdir()) / f"subtitles_{video_id}"
+    @staticmethod
+    def _has_manual_subtitles(info: VideoInfo, language: str) -> bool:
+        """Return whether the video lists manual subtitles in ``language``, including regional tracks such as ``en-US``."""
+        return any(track.split("-", maxsplit=1)[0] == language for track in info.subtitles)
 
     def _detect_language(self, info: VideoInfo) -> str:
         """
@@ -341,13 +280,13 @@ class VideoDataLoader:
             Language code (e.g., 'en', 'ru').
 
         Raises:
-            RuntimeError: If no subtitles are available.
+            NoSubtitlesError: If no subtitles are available.
         """
         if info.language:
             return info.language.split("-", maxsplit=1)[0]
 
         if not info.subtitles:
-            raise RuntimeError("no subtitles available")
+            raise NoSubtitlesError("no subtitles available")
 
         # Fallback to English if exists
         if "en" in info.subtitles:
@@ -355,44 +294,3 @@ class VideoDataLoader:
 
         # Get first available language from subtitles
         return next(iter(info.subtitles.keys())).split("-", maxsplit=1)[0]
-
-    def _find_subtitle_file(self, video_id: str, language: str) -> Path | None:
-        """
-        Find downloaded subtitle file for the given language.
-
-        Searches in order:
-        1. Exact language match (.en.srt or .en.vtt)
-        2. Auto-generated subtitles (.en_auto.srt or .en_auto.vtt)
-        3. Any matching file (.srt or .vtt)
-
-        Args:
-            language: Language code to search for.
-
-        Returns:
-            Path to subtitle file or None if not found.
-        """
-        for ext in (".srt", ".vtt"):
-            exact = self._get_subtitle_prefix(video_id).with_suffix(f".{language}{ext}")
-            if exact.exists():
-                return exact
-
-            auto = self._get_subtitle_prefix(video_id).with_suffix(f".{language}_auto{ext}")
-            if auto.exists():
-                return auto
-
-        candidates: list[Path] = []
-        for ext in (".srt", ".vtt"):
-            candidates.extend(Path(tempfile.gettempdir()).glob(f"subtitles_{video_id}*{ext}"))
-
-        if candidates:
-            return sorted(candidates)[0]
-
-        return None
-
-    def _cleanup_subtitle_files(self, video_id: str) -> None:
-        """Remove temporary subtitle files for this video."""
-        for path in Path(tempfile.gettempdir()).glob(f"subtitles_{video_id}*"):
-            try:
-                path.unlink(missing_ok=True)
-            except OSError:
-                logger.warning("Failed to cleanup temp subtitle file", extra={"path": str(path)})
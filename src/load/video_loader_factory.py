@@ -0,0 +1,83 @@
+"""
+Video loader registry.
+
+Picks the loader for a video URL by its provider. Every supported provider is served
+by ``VideoDataLoader`` by default; a deployment can register its own loader for a
+provider, e.g. one backed by a platform API, before loaders are created.
+"""
+
+from __future__ import annotations
+
+from collections.abc import Callable
+
+from ..config import Settings
+from .errors import InvalidVideoUrlError
+from .video_loader import VideoDataLoader, VideoTranscript
+from .video_provider import PROVIDERS, RegexProvider, find_provider
+
+VideoLoaderFactory = Callable[[Settings], VideoDataLoader]
+
+_factories: dict[str, VideoLoaderFactory] = {provider.name: VideoDataLoader for provider in PROVIDERS}
+
+
+def register_video_loader(provider: RegexProvider, factory: VideoLoaderFactory) -> None:
+    """
+    Register the loader of a provider, replacing the one registered before.
+
+    Args:
+        provider: Provider whose URLs the loader handles.
+        factory: Builds the loader from the application settings.
+    """
+    _factories[provider.name] = factory
+
+
+class ProviderVideoLoader:
+    """
+    Loads each video with the loader registered for its provider.
+
+    A loader is built the first time a provider's URL is loaded and reused afterwards,
+    so its cache connection, transcript backend and proxy rotation are shared.
+    """
+
+    def __init__(self, settings: Settings) -> None:
+        """
+        Initialize the loader without building any provider loaders yet.
+
+        Args:
+            settings: Application settings the provider loaders are built with.
+        """
+        self.settings = settings
+        self._loaders: dict[str, VideoDataLoader] = {}
+
+    def for_url(self, url: str) -> VideoDataLoader:
+        """
+        Return the loader of the provider a video URL belongs to.
+
+        Args:
+            url: Video URL.
+
+        Returns:
+            Loader ready to load the URL.
+
+        Raises:
+            InvalidVideoUrlError: If the URL does not belong to a supported provider.
+        """
+        provider = find_provider(url)
+        if provider is None:
+            raise InvalidVideoUrlError(f"no valid URL found: {url}")
+        if provider.name not in self._loaders:
+            self._loaders[provider.name] = _factories[provider.name](self.settings)
+        return self._loaders[provider.name]
+
+    async def load(self, url: str, refresh: bool = False) -> VideoTranscript:
+        """
+        Load the transcript of a video with the loader of its provider.
+
+        Args:
+            url: Video URL to process.
+            refresh: Download the transcript again even if it is cached, and replace the cached one.
+
+        Returns:
+            The video transcript.
+        """
+        return await self.for_url(url).load(url, refresh=refresh)
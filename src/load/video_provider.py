@@ -1,17 +1,39 @@
 """
 Video URL provider module.
 
-Extracts and validates video URLs from supported platforms:
-- YouTube (standard and Shorts)
+Matches and validates video URLs from supported platforms:
+- YouTube (standard, live, mobile, Music and Shorts)
 - VK Video
 
-Provides URL canonicalization and video ID extraction.
+Provides URL canonicalization, video ID and start timestamp extraction.
 """
 
 from __future__ import annotations
 
 import re
 from dataclasses import dataclass
+from urllib.parse import parse_qs, urlsplit
+
+from .errors import InvalidVideoUrlError
+
+# Timestamp parameter names, in priority order (``t=1m30s`` on youtu.be/watch links, ``start=90`` on embeds)
+TIMESTAMP_PARAMS = ("t", "start")
+# Timestamp value: plain seconds ("90", "90s") or units ("1h2m3s", "1m30s")
+_TIMESTAMP_RE = re.compile(r"(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s?)?")
+
+
+@dataclass(frozen=True)
+class ParsedURL:
+    """
+    Video reference parsed from a URL.
+
+    Attributes:
+        id: Video ID; playlist and other query parameters are ignored.
+        start_seconds: Playback start from a ``t``/``start`` parameter, or None if absent.
+    """
+
+    id: str
+    start_seconds: int | None = None
 
 
 @dataclass(frozen=True)
@@ -20,10 +42,12 @@ class RegexProvider:
     URL pattern matcher and canonicalizer for video platforms.
 
     Attributes:
+        name: Human-readable platform name for logs and user-facing messages.
         pattern: Compiled regex pattern for URL matching.
         canonical_url: URL template for canonical form (with %s for video ID).
     """
 
+    name: str
     pattern: re.Pattern[str]
     canonical_url: str
 
@@ -57,6 +81,26 @@ class RegexProvider:
             raise ValueError("no valid URL found")
         return match.group(1)
 
+    def parse(self, text: str) -> ParsedURL:
+        """
+        Extract video ID and start timestamp from URL.
+
+        Args:
+            text: Text containing the URL.
+
+        Returns:
+            ParsedURL with the video ID and start timestamp.
+
+        Raises:
+            ValueError: If no valid URL is found.
+        """
+        match = self.pattern.search(text)
+        if not match:
+            raise ValueError("no valid URL found")
+        # The pattern stops after the ID; parameters run until the next whitespace.
+        url = text[match.start() :].split(maxsplit=1)[0]
+        return ParsedURL(id=match.group(1), start_seconds=_parse_start_seconds(url))
+
     def extract_urls(self, text: str) -> list[str]:
         """
         Extract all matching URLs from text.
@@ -83,20 +127,24 @@ class RegexProvider:
         return self.canonical_url % video_id, video_id
 
 
-# YouTube standard video URL pattern (youtube.com/watch?v=ID or youtu.be/ID)
+# YouTube standard video URL pattern (youtube.com/watch?v=ID, youtube.com/live/ID or youtu.be/ID),
+# including the mobile (m.) and YouTube Music hosts. Embed URLs are not matched.
 YOUTUBE = RegexProvider(
-    pattern=re.compile(r"(?:https?://)?(?:www\.)?(?:youtube\.com/watch\?.*?v=|youtu\.be/)([a-zA-Z0-9_-]{11})"),
+    name="YouTube",
+    pattern=re.compile(r"(?:https?://)?(?:(?:www|m|music)\.)?(?:youtube\.com/(?:watch\?.*?v=|live/)|youtu\.be/)([a-zA-Z0-9_-]{11})"),
     canonical_url="https://www.youtube.com/watch?v=%s",
 )
 
 # YouTube Shorts URL pattern (youtube.com/shorts/ID)
 YOUTUBE_SHORT = RegexProvider(
+    name="YouTube Shorts",
     pattern=re.compile(r"(?:https?://)?(?:www\.)?youtube\.com/shorts/([A-Za-z0-9_-]{11})"),
     canonical_url="https://www.youtube.com/shorts/%s",
 )
 
 # VK Video URL pattern (vkvideo.ru/video-ID)
 VKVIDEO = RegexProvider(
+    name="VK Video",
     pattern=re.compile(r"(?:https?://)?(?:www\.)?vkvideo\.ru/(video-\d+_\d+)"),
     canonical_url="https://vkvideo.ru/%s",
 )
@@ -105,23 +153,20 @@ VKVIDEO = RegexProvider(
 PROVIDERS: tuple[RegexProvider, ...] = (YOUTUBE, YOUTUBE_SHORT, VKVIDEO)
 
 
-def extract_urls(text: str) -> list[str]:
+def find_provider(url: str) -> RegexProvider | None:
     """
-    Extract video URLs from text using all supported providers.
-
-    Returns URLs from the first matching provider (priority order:
-    YouTube standard, YouTube Shorts, VK Video).
+    Find the provider a URL belongs to.
 
     Args:
-        text: Text to search for video URLs.
+        url: Video URL.
 
     Returns:
-        List of extracted URLs (empty if none found).
+        First matching provider in priority order, or None if URL isn't supported.
     """
     for provider in PROVIDERS:
-        if provider.is_valid_url(text):
-            return provider.extract_urls(text)
-    return []
+        if provider.is_valid_url(url):
+            return provider
+    return None
 
 
 def build_video_source(url: str) -> tuple[str, str]:
@@ -135,9 +180,63 @@ def build_video_source(url: str) -> tuple[str, str]:
         Tuple of (canonical URL, video ID).
 
     Raises:
-        ValueError: If URL doesn't match any supported provider.
+        InvalidVideoUrlError: If URL doesn't match any supported provider.
     """
     for provider in PROVIDERS:
         if provider.is_valid_url(url):
             return provider.canonicalize(url)
+    raise InvalidVideoUrlError(f"no valid URL found: {url}")
+
+
+def parse_video_url(url: str) -> ParsedURL:
+    """
+    Parse video ID and start timestamp from a URL of any supported provider.
+
+    Args:
+        url: Video URL to parse.
+
+    Returns:
+        ParsedURL with the video ID and start timestamp.
+
+    Raises:
+        ValueError: If URL doesn't match any supported provider.
+    """
+    for provider in PROVIDERS:
+        if provider.is_valid_url(url):
+            return provider.parse(url)
     raise ValueError(f"no valid URL found: {url}")
+
+
+def find_video_id(url: str) -> str | None:
+    """
+    Return the video ID of a URL from any supported provider.
+
+    Args:
+        url: Video URL.
+
+    Returns:
+        Video ID, or None if URL doesn't match any supported provider.
+    """
+    for provider in PROVIDERS:
+        if provider.is_valid_url(url):
+            return provider.get_id(url)
+    return None
+
+
+def _parse_start_seconds(url: str) -> int | None:
+    """Read the start timestamp from the query string or fragment (``#t=30``) of a URL."""
+    parts = urlsplit(url if "://" in url else f"https://{url}")
+    for params in (parse_qs(parts.query), parse_qs(parts.fragment)):
+        for name in TIMESTAMP_PARAMS:
+            if name in params and (seconds := _parse_timestamp(params[name][0])) is not None:
+                return seconds
+    return None
+
+
+def _parse_timestamp(value: str) -> int | None:
+    """Convert a timestamp such as "90", "90s" or "1h2m3s" to seconds, or None if malformed."""
+    match = _TIMESTAMP_RE.fullmatch(value.strip())
+    if not match or not any(match.groups()):
+        return None
+    hours, minutes, seconds = (int(group or 0) for group in match.groups())
+    return hours * 3600 + minutes * 60 + seconds
@@ -0,0 +1,70 @@
+"""
+Word-level timings from YouTube json3 subtitles.
+
+json3 subtitles list caption events with a start time and duration; auto-generated
+captions split each event into one segment per word with an offset from the event
+start. The timings allow building interactive transcripts that follow playback.
+"""
+
+from __future__ import annotations
+
+import html
+import json
+from dataclasses import dataclass
+from typing import Any
+
+
+@dataclass(frozen=True)
+class WordTiming:
+    """
+    A word or caption segment and when it is spoken.
+
+    Attributes:
+        text: Word text, without surrounding whitespace.
+        start_ms: Start time in milliseconds.
+        duration_ms: Time until the next word of the same caption or the end of the caption, in milliseconds.
+    """
+
+    text: str
+    start_ms: int
+    duration_ms: int
+
+
+def parse_json3_words(text: str) -> tuple[WordTiming, ...]:
+    """
+    Parse the word timings of json3 subtitles.
+
+    Manual subtitles have one segment per caption, so their "words" are whole captions.
+
+    Args:
+        text: Raw json3 subtitle content.
+
+    Returns:
+        Word timings ordered as in the subtitles, or an empty tuple if the content is not valid json3.
+    """
+    try:
+        events = json.loads(text).get("events") or []
+    except (ValueError, AttributeError):
+        return ()
+
+    words: list[WordTiming] = []
+    for event in events:
+        words.extend(_event_words(event))
+    return tuple(words)
+
+
+def _event_words(event: dict[str, Any]) -> list[WordTiming]:
+    """Return the timed, non-blank segments of a json3 caption event."""
+    event_start = int(event.get("tStartMs", 0))
+    event_end = event_start + int(event.get("dDurationMs", 0))
+    segments = [
+        (event_start + int(segment.get("tOffsetMs", 0)), html.unescape(str(segment.get("utf8", ""))).strip())
+        for segment in event.get("segs") or []
+    ]
+    segments = [(start, word) for start, word in segments if word]
+
+    words = []
+    for i, (start, word) in enumerate(segments):
+        end = segments[i + 1][0] if i + 1 < len(segments) else event_end
+        words.append(WordTiming(word, start, max(0, end - start)))
+    return words
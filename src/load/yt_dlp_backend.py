@@ -0,0 +1,183 @@
+"""
+yt-dlp transcript backend.
+
+Downloads subtitles with yt-dlp into the temp directory, reads them and removes the files.
+"""
+
+from __future__ import annotations
+
+import logging
+import tempfile
+import threading
+from dataclasses import dataclass
+from functools import partial
+from pathlib import Path
+from typing import Any
+
+import yt_dlp
+
+from ..config import SUBTITLE_FORMATS, Settings
+from .transcript_backend import FetchCancelledError, RawSubtitles, TranscriptBackend
+from .yt_dlp_logger import YtDlpCaptureLogger
+from .yt_dlp_options import build_ydl_opts
+from .yt_dlp_proxies import ProxyRotator
+from .yt_dlp_retry import call_with_retry
+
+logger = logging.getLogger(__name__)
+
+
+@dataclass(frozen=True)
+class _SubtitleDownload:
+    """A subtitle download in progress: the video and language, the captured yt-dlp output and the cancellation flag."""
+
+    url: str
+    video_id: str
+    language: str
+    ydl_logger: YtDlpCaptureLogger
+    cancelled: threading.Event
+
+    def raise_if_cancelled(self) -> None:
+        """Raise FetchCancelledError if the request was cancelled."""
+        if self.cancelled.is_set():
+            raise FetchCancelledError(f"subtitle download cancelled: {self.url}")
+
+    def progress_hook(self, status: dict[str, Any]) -> None:
+        """yt-dlp progress hook aborting the download once the request is cancelled."""
+        self.raise_if_cancelled()
+
+
+class YtDlpTranscriptBackend(TranscriptBackend):
+    """Fetches subtitles with yt-dlp, retrying transient failures."""
+
+    def __init__(self, settings: Settings) -> None:
+        """
+        Initialize the backend.
+
+        Args:
+            settings: Application settings with yt-dlp and subtitle configuration.
+        """
+        self.settings = settings
+        self.proxy_rotator = ProxyRotator(settings.yt_dlp_proxies)
+
+    def fetch(self, url: str, video_id: str, language: str, has_manual_subtitles: bool, cancelled: threading.Event) -> RawSubtitles | None:
+        """
+        Download the subtitles of a video.
+
+        With ``prefer_manual_subtitles``, auto-generated captions are only downloaded if
+        the manual subtitles could not be. Downloaded and partial files are removed in any case.
+        """
+        download = _SubtitleDownload(url, video_id, language, YtDlpCaptureLogger(), cancelled)
+        # Manual subtitles are cleaner than auto-generated captions; only fall back to the latter without them.
+        manual_only = self.settings.prefer_manual_subtitles and has_manual_subtitles
+        try:
+            subtitle_file = self._download_subtitle_file(download, automatic=not manual_only)
+            if subtitle_file is None and manual_only:
+                logger.info("Manual subtitles not downloaded, falling back to auto-generated", extra={"url": url, "language": language})
+                subtitle_file = self._download_subtitle_file(download, automatic=True)
+
+            if subtitle_file is None:
+                logger.info("yt-dlp downloaded no subtitles", extra={"url": url, "yt_dlp_output": "\n".join(download.ydl_logger.messages)})
+                return None
+
+            content = subtitle_file.read_text(encoding="utf-8", errors="ignore")
+            return RawSubtitles(content, subtitle_file.suffix.lstrip("."))
+        finally:
+            self._cleanup_subtitle_files(video_id)
+
+    def _download_subtitle_file(self, download: _SubtitleDownload, automatic: bool) -> Path | None:
+        """Download subtitles, retrying transient failures, and return the downloaded file if any."""
+        try:
+            call_with_retry(
+                partial(self._download_subtitles, download, automatic),
+                "download subtitles",
+                self.settings.yt_dlp_max_attempts,
+                self.settings.yt_dlp_retry_delay_seconds,
+                lambda: {"url": download.url, "yt_dlp_output": "\n".join(download.ydl_logger.messages)},
+            )
+        except RuntimeError:
+            # The progress hook aborts through yt-dlp, which may wrap the error; report the cancellation as such.
+            download.raise_if_cancelled()
+            raise
+        return self._find_subtitle_file(download.video_id, download.language)
+
+    def _download_subtitles(self, download: _SubtitleDownload, automatic: bool = True) -> None:
+        """Download subtitles into the temp directory, including auto-generated ones if ``automatic``; stop once cancelled."""
+        download.raise_if_cancelled()
+        download.ydl_logger.messages.clear()
+        ydl_opts = build_ydl_opts(
+            self.settings.yt_dlp_additional_options,
+            {
+                "no_progress": True,
+                "skip_download": True,
+                "writesubtitles": True,
+                "writeautomaticsub": automatic,
+                # Entries are regular expressions; "en-.*" also takes regional manual tracks such as en-US.
+                "subtitleslangs": [download.language, f"{download.language}-.*", f"{download.language}_auto", "-live_chat"],
+                "subtitlesformat": "/".join((*self._subtitle_formats(), "best")),
+                "outtmpl": self._get_subtitle_template_path(download.video_id),
+                "logger": download.ydl_logger,
+                # yt-dlp reports subtitle download progress too; raising from the hook aborts the download.
+                "progress_hooks": [download.progress_hook],
+                "quiet": False,
+                "no_warnings": False,
+            },
+            cookies_file=self.settings.yt_dlp_cookies_file,
+            proxy=self.proxy_rotator.next(),
+            allow_any_options=self.settings.yt_dlp_allow_any_options,
+        )
+        with yt_dlp.YoutubeDL(ydl_opts) as ydl:
+            ydl.extract_info(download.url, download=True)
+
+    def _subtitle_formats(self) -> tuple[str, ...]:
+        """Return the supported subtitle formats, the configured one first; json3 first if word timings are enabled."""
+        preferred = "json3" if self.settings.feature_flags.word_timings else self.settings.subtitle_format
+        return (preferred, *(fmt for fmt in SUBTITLE_FORMATS if fmt != preferred))
+
+    def _get_subtitle_template_path(self, video_id: str) -> str:
+        """Generate template path for subtitle files in temp directory."""
+        temp_dir = Path(tempfile.gettempdir())
+        return str(temp_dir / f"subtitles_{video_id}.%(ext)s")
+
+    def _get_subtitle_prefix(self, video_id: str) -> Path:
+        """Get prefix for subtitle file names in temp directory."""
+        return Path(tempfile.gettempdir()) / f"subtitles_{video_id}"
+
+    def _find_subtitle_file(self, video_id: str, language: str) -> Path | None:
+        """
+        Find downloaded subtitle file for the given language.
+
+        Searches each format, the configured one first, in order:
+        1. Exact language match (.en.srt)
+        2. Auto-generated subtitles (.en_auto.srt)
+        3. Any matching file (.srt, .vtt or .json3)
+
+        Args:
+            language: Language code to search for.
+
+        Returns:
+            Path to subtitle file or None if not found.
+        """
+        extensions = [f".{fmt}" for fmt in self._subtitle_formats()]
+        for ext in extensions:
+            exact = self._get_subtitle_prefix(video_id).with_suffix(f".{language}{ext}")
+            if exact.exists():
+                return exact
+
+            auto = self._get_subtitle_prefix(video_id).with_suffix(f".{language}_auto{ext}")
+            if auto.exists():
+                return auto
+
+        for ext in extensions:
+            candidates = sorted(Path(tempfile.gettempdir()).glob(f"subtitles_{video_id}*{ext}"))
+            if candidates:
+                return candidates[0]
+
+        return None
+
+    def _cleanup_subtitle_files(self, video_id: str) -> None:
+        """Remove temporary subtitle files for this video."""
+        for path in Path(tempfile.gettempdir()).glob(f"subtitles_{video_id}*"):
+            try:
+                path.unlink(missing_ok=True)
+            except OSError:
+                logger.warning("Failed to cleanup temp subtitle file", extra={"path": str(path)})
@@ -0,0 +1,141 @@
+"""
+yt-dlp option building.
+
+Combines the default yt-dlp options with per-call options, the user-provided
+``YT_DLP_ADDITIONAL_OPTIONS`` and dedicated, validated settings such as the cookies file
+and proxy.
+"""
+
+from __future__ import annotations
+
+import logging
+from typing import Any
+
+logger = logging.getLogger(__name__)
+
+# Option name prefixes accepted from YT_DLP_ADDITIONAL_OPTIONS: network, format and extractor tuning.
+# Anything else (e.g. --exec, --downloader, --config-location, --output) is skipped unless
+# YT_DLP_ALLOW_ANY_OPTIONS is set, since it could run commands or write arbitrary files.
+ALLOWED_OPTION_PREFIXES = (
+    "--add-header",
+    "--cookies",
+    "--extractor-args",
+    "--extractor-retries",
+    "--force-ipv4",
+    "--force-ipv6",
+    "--format",
+    "--geo-bypass",
+    "--impersonate",
+    "--legacy-server-connect",
+    "--limit-rate",
+    "--max-sleep-interval",
+    "--no-check-certificate",
+    "--referer",
+    "--retries",
+    "--sleep",
+    "--socket-timeout",
+    "--source-address",
+    "--throttled-rate",
+    "--user-agent",
+)
+
+
+def _is_allowed_option(option: str) -> bool:
+    """Check if an option name is on the allow-list."""
+    return option.startswith(ALLOWED_OPTION_PREFIXES)
+
+
+def _is_safe_option_value(value: str) -> bool:
+    """
+    Check if an option value is safe (no shell injection or path traversal).
+
+    Args:
+        value: The option value to validate.
+
+    Returns:
+        True if safe, False otherwise.
+    """
+    # Block path traversal
+    if ".." in value or value.startswith("/"):
+        return False
+    # Block shell metacharacters
+    if any(char in value for char in (";", "|", "&", "$", "`", "(", ")", "<", ">", "\\", "\n", "\r")):
+        return False
+    return True
+
+
+def _parse_additional_options(additional_options: tuple[str, ...], allow_any: bool = False) -> dict[str, Any]:
+    """
+    Convert command-line style options into yt-dlp keyword options.
+
+    Only options starting with ``--`` are used; options off the allow-list (unless
+    ``allow_any``) and unsafe values are skipped together with their value.
+    """
+    opts: dict[str, Any] = {}
+    i = 0
+    while i < len(additional_options):
+        opt = additional_options[i]
+        has_value = i + 1 < len(additional_options) and not additional_options[i + 1].startswith("--")
+        if opt.startswith("--") and not allow_any and not _is_allowed_option(opt):
+            logger.warning("Skipping yt-dlp option not on the allow-list", extra={"option": opt})
+            i += 2 if has_value else 1
+            continue
+        if opt.startswith("--"):
+            key = opt[2:].replace("-", "_")
+            # Check if next option is a value (not another flag)
+            if has_value:
+                value = additional_options[i + 1]
+                # Validate value to prevent injection attacks
+                if not _is_safe_option_value(value):
+                    logger.warning(
+                        "Skipping unsafe yt-dlp option value",
+                        extra={"key": key, "value_length": len(value)},
+                    )
+                    i += 2
+                    continue
+                opts[key] = value
+                i += 1
+            else:
+                opts[key] = True
+        i += 1
+    return opts
+
+
+def build_ydl_opts(
+    additional_options: tuple[str, ...],
+    extra_options: dict[str, Any] | None = None,
+    cookies_file: str | None = None,
+    proxy: str | None = None,
+    allow_any_options: bool = False,
+) -> dict[str, Any]:
+    """
+    Build yt-dlp options with additional user options.
+
+    Args:
+        additional_options: User-provided options from ``YT_DLP_ADDITIONAL_OPTIONS``.
+        extra_options: Additional options to merge with defaults.
+        cookies_file: Netscape-format cookies file passed to yt-dlp, if configured.
+        proxy: Proxy URL for this call, if configured.
+        allow_any_options: Accept user options that are not on ``ALLOWED_OPTION_PREFIXES``.
+
+    Returns:
+        Dictionary of yt-dlp options.
+
+    Note:
+        User-provided options are filtered to prevent injection attacks.
+        Only allow-listed options starting with -- and safe values are used.
+    """
+    opts: dict[str, Any] = {
+        "quiet": True,
+        "no_warnings": True,
+        "extract_flat": False,
+    }
+    if extra_options:
+        opts.update(extra_options)
+    opts.update(_parse_additional_options(additional_options, allow_any_options))
+    # The cookies path is validated at startup, so it may be absolute unlike user option values.
+    if cookies_file:
+        opts["cookiefile"] = cookies_file
+    if proxy:
+        opts["proxy"] = proxy
+    return opts
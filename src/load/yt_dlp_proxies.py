@@ -0,0 +1,40 @@
+"""
+Proxy rotation for yt-dlp.
+
+Spreads yt-dlp requests across the proxies configured in ``YT_DLP_PROXIES``
+to avoid IP-based rate limiting from YouTube.
+"""
+
+from __future__ import annotations
+
+import threading
+
+
+class ProxyRotator:
+    """Hands out proxies round-robin; safe to use from the worker threads running yt-dlp."""
+
+    def __init__(self, proxies: tuple[str, ...]) -> None:
+        """
+        Initialize the rotator.
+
+        Args:
+            proxies: Proxy URLs to rotate through; empty disables proxying.
+        """
+        self._proxies = proxies
+        self._index = 0
+        self._lock = threading.Lock()
+
+    def next(self) -> str | None:
+        """
+        Return the proxy for the next yt-dlp call.
+
+        Returns:
+            Proxy URL, or None if no proxies are configured.
+        """
+        if not self._proxies:
+            return None
+
+        with self._lock:
+            proxy = self._proxies[self._index]
+            self._index = (self._index + 1) % len(self._proxies)
+        return proxy
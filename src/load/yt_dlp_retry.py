@@ -0,0 +1,127 @@
+"""
+Retry policy for yt-dlp calls.
+
+Only transient failures (network errors, timeouts, throttling and 5xx responses)
+are retried, with exponential backoff. Permanent errors such as "Video unavailable"
+or "Private video" fail on the first attempt, raised as the matching VideoLoadError.
+"""
+
+from __future__ import annotations
+
+import logging
+import time
+from collections.abc import Callable
+from typing import Any, TypeVar
+
+from .errors import VideoPrivateError, VideoUnavailableError
+
+logger = logging.getLogger(__name__)
+
+# Lowercase fragments of yt-dlp/urllib error messages that indicate a transient failure.
+TRANSIENT_ERROR_MARKERS = (
+    "timed out",
+    "timeout",
+    "network",
+    "connection",
+    "temporary failure",
+    "name resolution",
+    "remote end closed",
+    "http error 429",
+    "http error 500",
+    "http error 502",
+    "http error 503",
+    "http error 504",
+)
+
+# Lowercase fragments of yt-dlp error messages for videos that cannot be watched, checked in order.
+PRIVATE_VIDEO_MARKERS = ("private video", "video is private")
+UNAVAILABLE_VIDEO_MARKERS = (
+    "video unavailable",
+    "has been removed",
+    "available in your country",
+    "members-only",
+    "join this channel",
+    "sign in to confirm your age",
+    "this video is not available",
+)
+
+T = TypeVar("T")
+
+
+def is_transient_error(exc: Exception) -> bool:
+    """
+    Check whether a yt-dlp failure is worth retrying.
+
+    Args:
+        exc: Error raised by yt-dlp.
+
+    Returns:
+        True for network errors and timeouts, False otherwise.
+    """
+    if isinstance(exc, TimeoutError | ConnectionError):
+        return True
+    message = str(exc).lower()
+    return any(marker in message for marker in TRANSIENT_ERROR_MARKERS)
+
+
+def call_with_retry(
+    action: Callable[[], T],
+    description: str,
+    attempts: int,
+    base_delay: float,
+    log_extra: Callable[[], dict[str, Any]],
+) -> T:
+    """
+    Run a blocking yt-dlp call, retrying transient failures.
+
+    Args:
+        action: Zero-argument callable performing the yt-dlp call.
+        description: What the call does, e.g. "load video info"; used in logs and errors.
+        attempts: Total number of attempts.
+        base_delay: Delay in seconds before the first retry; doubled on each retry.
+        log_extra: Returns context to attach to the failure log of each attempt.
+
+    Returns:
+        The result of the call.
+
+    Raises:
+        VideoPrivateError: If the video is private.
+        VideoUnavailableError: If the video was removed or cannot be watched from here.
+        RuntimeError: If any other error is not transient or retries are exhausted.
+    """
+    attempt = 1
+    while True:
+        try:
+            return action()
+        except Exception as exc:
+            transient = is_transient_error(exc)
+            logger.warning(
+                "yt-dlp call failed",
+                extra={"operation": description, "attempt": attempt, "transient": transient, "error": str(exc), **log_extra()},
+            )
+            if not transient:
+                raise permanent_error(f"Failed to {description}: {exc}", exc) from exc
+            if attempt >= attempts:
+                raise RuntimeError(f"Failed to {description} after {attempt} attempts: {exc}") from exc
+
+        time.sleep(base_delay * 2 ** (attempt - 1))
+        attempt += 1
+
+
+def permanent_error(message: str, exc: Exception) -> RuntimeError:
+    """
+    Build the error raised for a yt-dlp failure that is not retried.
+
+    Args:
+        message: Error message.
+        exc: Error raised by yt-dlp.
+
+    Returns:
+        A VideoPrivateError or VideoUnavailableError if the video cannot be watched, a RuntimeError otherwise.
+    """
+    cause = str(exc).lower()
+    if any(marker in cause for marker in PRIVATE_VIDEO_MARKERS):
+        return VideoPrivateError(message)
+    if any(marker in cause for marker in UNAVAILABLE_VIDEO_MARKERS):
+        return VideoUnavailableError(message)
+    return RuntimeError(message)
@@ -12,6 +12,7 @@ from pathlib import Path
 import i18n
 
 DEFAULT_LOCALE = "en"
+LOCALES_PATH = Path(__file__).resolve().parents[1] / "locales"
 
 
 class I18nState:
@@ -34,8 +35,7 @@ def _setup_i18n() -> None:
     if _state.is_initialized:
         return
 
-    locales_path = Path(__file__).resolve().parents[1] / "locales"
-    i18n.load_path.append(str(locales_path))
+    i18n.load_path.append(str(LOCALES_PATH))
     i18n.set("filename_format", "locale.{locale}.{format}")
     i18n.set("file_format", "yml")
     i18n.set("fallback", DEFAULT_LOCALE)
@@ -70,6 +70,16 @@ def normalize_locale(locale: str | None) -> str:
     return normalized.split("-", maxsplit=1)[0]
 
 
+def supported_locales() -> tuple[str, ...]:
+    """
+    List the locales that have a translation file.
+
+    Returns:
+        Sorted locale codes (e.g., ('ar', 'de', 'en', ...)).
+    """
+    return tuple(sorted(path.name.split(".")[1] for path in LOCALES_PATH.glob("locale.*.yml")))
+
+
 def translate(key: str, locale: str | None = None, **kwargs: object) -> str:
     """
     Translate a localization key to the specified language.
@@ -0,0 +1,55 @@
+"""
+Request-scoped logging context.
+
+Fields bound for the duration of a request (e.g. the user and video being processed)
+are attached to every log record emitted while handling it, so all lines of one
+request can be correlated.
+"""
+
+from __future__ import annotations
+
+import logging
+from collections.abc import Iterator
+from contextlib import contextmanager
+from contextvars import ContextVar
+
+_log_context: ContextVar[dict[str, object] | None] = ContextVar("log_context", default=None)
+
+
+@contextmanager
+def bind_log_context(**fields: object) -> Iterator[None]:
+    """
+    Attach fields to every log record emitted inside the block.
+
+    Args:
+        **fields: Log fields to attach, merged over the enclosing context.
+    """
+    token = _log_context.set({**(_log_context.get() or {}), **fields})
+    try:
+        yield
+    finally:
+        _log_context.reset(token)
+
+
+def add_log_context(**fields: object) -> None:
+    """
+    Add fields to the innermost bound context, e.g. once the video of a request is known.
+
+    Outside of ``bind_log_context`` this does nothing, so fields cannot leak between requests.
+
+    Args:
+        **fields: Log fields to attach.
+    """
+    context = _log_context.get()
+    if context is not None:
+        context.update(fields)
+
+
+class LogContextFilter(logging.Filter):
+    """Copies the bound context fields onto log records; explicit ``extra`` fields take precedence."""
+
+    def filter(self, record: logging.LogRecord) -> bool:
+        for key, value in (_log_context.get() or {}).items():
+            if not hasattr(record, key):
+                setattr(record, key, value)
+        return True
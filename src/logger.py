@@ -8,6 +8,8 @@ Suppresses verbose HTTP client logs to reduce noise.
 import logging
 import os
 
+from .log_context import LogContextFilter
+
 
 class CustomFormatter(logging.Formatter):
     """
@@ -92,4 +94,5 @@ def configure_logging() -> None:
             datefmt="%Y-%m-%d %H:%M:%S",
         )
     )
+    handler.addFilter(LogContextFilter())
     root_logger.addHandler(handler)
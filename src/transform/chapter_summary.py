@@ -0,0 +1,61 @@
+"""
+Chapter-by-chapter summaries.
+
+With ``ENABLE_CHAPTER_SUMMARIES``, videos that have chapters are summarized one chapter
+at a time and the summaries are joined under the chapter titles and start times.
+Videos without usable chapters get a whole-video summary as usual.
+"""
+
+from __future__ import annotations
+
+import logging
+
+from ..load.video_loader import VideoTranscript
+from ..utils.text import format_duration
+from .summarization import OpenAISummarizer
+from .summary_markdown import escape_markdown
+from .summary_style import SummaryStyle
+
+logger = logging.getLogger(__name__)
+
+# A single chapter is just the whole video
+MIN_CHAPTERS = 2
+# Above this, one request per chapter costs too much; the whole video is summarized instead
+MAX_CHAPTERS = 20
+
+
+async def summarize_transcript(  # noqa: PLR0913
+    summarizer: OpenAISummarizer,
+    transcript: VideoTranscript,
+    locale: str,
+    style: SummaryStyle | None = None,
+    by_chapters: bool = False,
+    refresh: bool = False,
+) -> str:
+    """
+    Summarize a video transcript, chapter by chapter if requested and possible.
+
+    Args:
+        summarizer: Summarizer used for the whole video or each chapter.
+        transcript: Video transcript, with chapters if the video has them.
+        locale: Target locale of the summary.
+        style: Requested summary style; defaults to the style configured for the locale.
+        by_chapters: Summarize each chapter separately when the video has between
+            ``MIN_CHAPTERS`` and ``MAX_CHAPTERS`` chapters with text.
+        refresh: Bypass the summary cache and replace the cached summaries.
+
+    Returns:
+        The summary; chapter summaries are headed by the chapter title and start time.
+    """
+    chapters = [chapter for chapter in transcript.chapters if chapter.transcript]
+    if not by_chapters or not MIN_CHAPTERS <= len(chapters) <= MAX_CHAPTERS:
+        return await summarizer.summarize(transcript.transcript, locale, style, refresh=refresh)
+
+    logger.info("Summarizing by chapters", extra={"video_id": transcript.id, "chapters": len(chapters)})
+    sections = []
+    for chapter in chapters:
+        summary = await summarizer.summarize(chapter.transcript, locale, style, refresh=refresh)
+        start = format_duration(chapter.start)
+        heading = f"{escape_markdown(chapter.title)} ({start})" if chapter.title else start
+        sections.append(f"**{heading}**\n{summary.strip()}")
+    return "\n\n".join(sections)
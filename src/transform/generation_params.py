@@ -0,0 +1,60 @@
+"""
+Sampling parameters of summary completions.
+
+``OPENAI_TEMPERATURE`` and ``OPENAI_MAX_TOKENS`` set the defaults; callers may
+override either per summary. Unset parameters are not sent, so the API default applies.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from typing import Any
+
+from ..config import MAX_OPENAI_TEMPERATURE, MIN_OPENAI_TEMPERATURE
+
+
+@dataclass(frozen=True)
+class GenerationParams:
+    """
+    Sampling parameters sent with a chat completion request.
+
+    Attributes:
+        temperature: Sampling temperature between 0 and 2, or None for the API default.
+        max_tokens: Maximum number of tokens in the summary, or None for no limit.
+    """
+
+    temperature: float | None = None
+    max_tokens: int | None = None
+
+    def __post_init__(self) -> None:
+        if self.temperature is not None and not MIN_OPENAI_TEMPERATURE <= self.temperature <= MAX_OPENAI_TEMPERATURE:
+            raise ValueError(f"temperature must be between {MIN_OPENAI_TEMPERATURE:g} and {MAX_OPENAI_TEMPERATURE:g}")
+        if self.max_tokens is not None and self.max_tokens < 1:
+            raise ValueError("max_tokens must be greater than 0")
+
+    def override(self, overrides: GenerationParams | None) -> GenerationParams:
+        """Return these parameters with every parameter set in ``overrides`` replaced."""
+        if overrides is None:
+            return self
+        return GenerationParams(
+            temperature=self.temperature if overrides.temperature is None else overrides.temperature,
+            max_tokens=self.max_tokens if overrides.max_tokens is None else overrides.max_tokens,
+        )
+
+    def request_kwargs(self) -> dict[str, Any]:
+        """Return the parameters that are set, as keyword arguments of ``chat.completions.create``."""
+        kwargs: dict[str, Any] = {}
+        if self.temperature is not None:
+            kwargs["temperature"] = self.temperature
+        if self.max_tokens is not None:
+            kwargs["max_tokens"] = self.max_tokens
+        return kwargs
+
+    def cache_suffix(self) -> str:
+        """Return a cache key suffix identifying the parameters, empty when none are set."""
+        suffix = ""
+        if self.temperature is not None:
+            suffix += f":t{self.temperature:g}"
+        if self.max_tokens is not None:
+            suffix += f":m{self.max_tokens}"
+        return suffix
@@ -0,0 +1,40 @@
+"""
+HTTP client for LLM API calls.
+
+The summarizer keeps one client for its lifetime, so connections to the LLM API
+are pooled and reused between summaries instead of being opened for each request.
+"""
+
+from __future__ import annotations
+
+import httpx
+from openai import DefaultAsyncHttpxClient
+
+from ..config import Settings
+
+CONNECT_TIMEOUT_SECONDS = 10.0
+# Idle connections are closed after this long; LLM APIs usually drop them after about a minute anyway
+KEEPALIVE_EXPIRY_SECONDS = 30.0
+
+
+def build_http_client(settings: Settings) -> httpx.AsyncClient:
+    """
+    Build the pooled HTTP client used for every LLM request.
+
+    The pool holds one connection per concurrently handled message, and connecting
+    fails fast while a slow answer may take up to ``openai_timeout_seconds``.
+
+    Args:
+        settings: Application settings with the OpenAI timeout and bot concurrency.
+
+    Returns:
+        HTTP client to pass to the OpenAI client.
+    """
+    return DefaultAsyncHttpxClient(
+        timeout=httpx.Timeout(settings.openai_timeout_seconds, connect=CONNECT_TIMEOUT_SECONDS),
+        limits=httpx.Limits(
+            max_connections=settings.bot_max_concurrency,
+            max_keepalive_connections=settings.bot_max_concurrency,
+            keepalive_expiry=KEEPALIVE_EXPIRY_SECONDS,
+        ),
+    )
@@ -0,0 +1,62 @@
+"""
+Active summarization model.
+
+Lets operators switch the model summaries are written with at runtime, among the
+models allowed by ``OPENAI_MODEL`` and ``OPENAI_MODELS``, backed by the cache provider.
+"""
+
+from __future__ import annotations
+
+from ..cache import CacheProvider
+from ..config import Settings
+
+cache_key = "active_model:"
+
+# The choice is long-lived; with Valkey it also survives restarts and is shared by all instances.
+DEFAULT_MODEL_SELECTION_TTL_SECONDS = 365 * 24 * 60 * 60
+
+
+class ModelSelection:
+    """Remembers which of the allowed models new summaries are written with."""
+
+    def __init__(self, provider: CacheProvider, settings: Settings, ttl_seconds: int = DEFAULT_MODEL_SELECTION_TTL_SECONDS) -> None:
+        """
+        Initializes the ModelSelection.
+
+        Args:
+            provider: The cache provider for state management.
+            settings: Application settings with the default and allowed models.
+            ttl_seconds: How long a choice is kept.
+        """
+
+        self.provider = provider
+        self.settings = settings
+        self.ttl_seconds = ttl_seconds
+
+    def allowed_models(self) -> tuple[str, ...]:
+        """Returns the models that can be selected, ``OPENAI_MODEL`` first."""
+        return tuple(dict.fromkeys((self.settings.openai_model, *self.settings.openai_models)))
+
+    async def get(self) -> str:
+        """
+        Returns the active model.
+
+        Returns:
+            The selected model, or ``OPENAI_MODEL`` if none is selected or the selected one is no longer allowed.
+        """
+        model = await self.provider.get(cache_key)
+        return model if model in self.allowed_models() else self.settings.openai_model
+
+    async def set(self, model: str) -> None:
+        """
+        Selects the model new summaries are written with.
+
+        Args:
+            model: One of the allowed models.
+
+        Raises:
+            ValueError: If the model is not allowed.
+        """
+        if model not in self.allowed_models():
+            raise ValueError(f"model {model!r} is not allowed")
+        await self.provider.put(cache_key, model, self.ttl_seconds)
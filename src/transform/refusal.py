@@ -0,0 +1,34 @@
+"""
+LLM refusal detection.
+
+Recognizes answers in which the model declines to summarize instead of
+producing a summary, so they are reported as errors rather than cached and sent.
+"""
+
+from __future__ import annotations
+
+import re
+
+# Refusals are short; a long answer that happens to start like one is a real summary.
+MAX_REFUSAL_LENGTH = 400
+
+_REFUSAL_RE = re.compile(
+    r"\A\W*(?:(?:i['’]m sorry|i am sorry|sorry|unfortunately|apologies)\W*(?:but\W+)?)?"
+    r"(?:as an ai[^,]*,\s*)?"
+    r"i\s+(?:cannot|can['’]?t|can not|am unable to|['’]m unable to|am not able to|won['’]t|will not)\s+"
+    r"(?:help|assist|summari[sz]e|provide|comply|fulfill|complete|process|do that)",
+    re.IGNORECASE,
+)
+
+
+def is_refusal(text: str) -> bool:
+    """
+    Check whether an LLM answer is a refusal rather than a summary.
+
+    Args:
+        text: Model output.
+
+    Returns:
+        True if the answer declines the request.
+    """
+    return len(text) <= MAX_REFUSAL_LENGTH and bool(_REFUSAL_RE.match(text.strip()))
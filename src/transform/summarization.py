@@ -7,18 +7,49 @@ timeout handling, and localization support.
 
 from __future__ import annotations
 
+import asyncio
+import dataclasses
 import hashlib
 import logging
 import time
 
-from openai import AsyncOpenAI
+from openai import APITimeoutError, AsyncOpenAI
+from openai.types.chat import ChatCompletionMessageParam
 
 from ..cache import CacheProvider, get_cache_provider
 from ..config import Settings
-from ..localization import translate
+from ..localization import normalize_locale
+from .generation_params import GenerationParams
+from .llm_http import build_http_client
+from .model_selection import ModelSelection
+from .refusal import is_refusal
+from .summary_cleanup import clean_summary
+from .summary_prompt import build_prompt, with_limit_note
+from .summary_style import SummaryStyle, style_instruction
+from .token_estimate import estimate_tokens
+from .transcript_limit import TranscriptLimitPolicy, limit_transcript
+from .usage import LLMUsage, UsageTotals
 
 logger = logging.getLogger(__name__)
 cache_prefix = "summary:"
+# Bump when the prompt or style instructions change so cached summaries from the old prompt are not reused.
+prompt_version = 1
+
+
+class SummarizationTimeoutError(RuntimeError):
+    """Raised when the LLM does not answer within the configured timeout."""
+
+
+class SummaryUnavailableError(RuntimeError):
+    """Raised when the LLM answers without a usable summary."""
+
+
+class EmptySummaryError(SummaryUnavailableError):
+    """Raised when the LLM returns empty or whitespace-only content."""
+
+
+class SummaryRefusedError(SummaryUnavailableError):
+    """Raised when the LLM declines to summarize the text."""
 
 
 class OpenAISummarizer:
@@ -30,34 +61,79 @@ class OpenAISummarizer:
     - Automatic retries with exponential backoff
     - Timeout handling
     - Locale-aware system prompts
+    - Token usage accounting
+    - Cleanup of boilerplate around the summary
+    - Transcript length limit with a note in the summary when applied
 
     Attributes:
         settings: Application configuration.
+        model_selection: Model chosen at runtime, if any; ``settings.openai_model`` otherwise.
         client: OpenAI API client instance.
+        usage_totals: Token usage accumulated since startup.
     """
 
-    def __init__(self, settings: Settings) -> None:
+    def __init__(self, settings: Settings, model_selection: ModelSelection | None = None) -> None:
         """
         Initialize the summarizer with API client.
 
         Args:
             settings: Application settings with API credentials.
+            model_selection: Where the active model is read from on each request, e.g. as switched with /model.
         """
         self.settings = settings
+        self.model_selection = model_selection
         self.cache_provider: CacheProvider = get_cache_provider(settings)
         self.client = AsyncOpenAI(
             base_url=settings.openai_base_url,
             api_key=settings.openai_api_key,
             max_retries=settings.openai_max_retries,
+            http_client=build_http_client(settings),
         )
+        self.usage_totals = UsageTotals()
+
+    def default_style(self, locale: str) -> SummaryStyle:
+        """Return the summary style used when none is requested: ``SUMMARY_STYLE_<LANG>`` for the locale, else ``SUMMARY_STYLE``."""
+        styles = self.settings.summary_styles_by_language
+        return SummaryStyle(styles.get(normalize_locale(locale), self.settings.summary_style))
+
+    def _generation_params(self) -> GenerationParams:
+        """Return the sampling parameters configured with ``OPENAI_TEMPERATURE`` and ``OPENAI_MAX_TOKENS``."""
+        return GenerationParams(temperature=self.settings.openai_temperature, max_tokens=self.settings.openai_max_tokens)
 
-    async def summarize(self, text: str, locale: str) -> str:
+    async def active_model(self) -> str:
+        """Return the model summaries are written with unless one is requested."""
+        if self.model_selection is None:
+            return self.settings.openai_model
+        return await self.model_selection.get()
+
+    def get_usage_totals(self) -> UsageTotals:
+        """Return a snapshot of the token usage accumulated since startup."""
+        return dataclasses.replace(self.usage_totals)
+
+    async def summarize(  # noqa: PLR0913
+        self,
+        text: str,
+        locale: str,
+        style: SummaryStyle | None = None,
+        model: str | None = None,
+        params: GenerationParams | None = None,
+        refresh: bool = False,
+    ) -> str:
         """
         Summarize text.
 
+        Summaries are cached per model and prompt version, so switching either
+        produces a fresh summary while the previous ones remain available.
+        Text longer than ``settings.max_transcript_chars`` is shortened first, and
+        the summary ends with a note saying how.
+
         Args:
             text: Input text to summarize.
             locale: Target locale for system prompt localization.
+            style: Requested summary style; defaults to the style configured for the locale.
+            model: Model to use instead of the active one.
+            params: Sampling parameters overriding ``OPENAI_TEMPERATURE`` and ``OPENAI_MAX_TOKENS``.
+            refresh: Summarize again even if a summary is cached, and replace the cached one.
 
         Returns:
             Generated summary text.
@@ -67,43 +143,81 @@ class OpenAISummarizer:
         if not text:
             raise ValueError("text must be a non-empty string")
 
+        limited = limit_transcript(text, self.settings.max_transcript_chars, TranscriptLimitPolicy(self.settings.transcript_limit_policy))
+        if limited.applied_policy:
+            logger.info(
+                "Transcript over length limit",
+                extra={"text_length": len(text), "max_chars": self.settings.max_transcript_chars, "policy": limited.applied_policy.value},
+            )
+            text = limited.text
+
+        style = style or self.default_style(locale)
         video_hash = self._text_hash(text)
-        cache_key = f"{cache_prefix}:{video_hash}:{locale}"
-        cached_summary = await self.cache_provider.get(cache_key)
+        model = model or await self.active_model()
+        cache_key = f"{cache_prefix}:{video_hash}:{model}:p{prompt_version}:{locale}"
+        if style is not SummaryStyle.DEFAULT:
+            cache_key = f"{cache_key}:{style.value}"
+        params = self._generation_params().override(params)
+        cache_key += params.cache_suffix()
+        cached_summary = None if refresh else await self.cache_provider.get(cache_key)
         if cached_summary:
-            logger.debug("Summary loaded from cache", extra={"locale": locale})
-            return cached_summary
+            logger.debug("Summary loaded from cache", extra={"locale": locale, "style": style.value})
+            return with_limit_note(cached_summary, limited.applied_policy, locale)
 
-        summary = await self._summarize(text, locale)
+        summary = await self._summarize(text, locale, style, model, params)
         await self.cache_provider.put(
             cache_key,
             summary,
             self.settings.cache_summary_ttl_seconds,
         )
-        return summary
+        return with_limit_note(summary, limited.applied_policy, locale)
 
-    async def _summarize(self, text: str, locale: str) -> str:
+    async def _summarize(
+        self,
+        text: str,
+        locale: str,
+        style: SummaryStyle = SummaryStyle.DEFAULT,
+        model: str | None = None,
+        params: GenerationParams | None = None,
+    ) -> str:
         """
         Summarize text using the configured LLM model.
 
         Args:
             text: Input text to summarize.
             locale: Target locale for system prompt localization.
+            style: Requested summary style.
+            model: Model to use instead of ``settings.openai_model``.
+            params: Sampling parameters; defaults to the configured ones.
 
         Returns:
             Generated summary text.
 
         Raises:
+            SummarizationTimeoutError: If the LLM does not answer within ``openai_timeout_seconds``.
+            EmptySummaryError: If the LLM returns no text.
+            SummaryRefusedError: If the LLM declines to summarize.
             RuntimeError: If summarization fails after all retries.
         """
         if not locale:
             raise ValueError("locale must be a non-empty string")
 
+        model = model or self.settings.openai_model
+        prompt = build_prompt(text, locale)
         logger.info(
             "Summarizing text",
-            extra={"locale": locale, "text_length": len(text), "model": self.settings.openai_model},
+            extra={
+                "locale": locale,
+                "text_length": len(text),
+                "estimated_tokens": await asyncio.to_thread(estimate_tokens, prompt, model),
+                "model": model,
+                "style": style.value,
+            },
         )
-        prompt = translate("openai.prompt", locale=locale, text=text)
+        messages: list[ChatCompletionMessageParam] = [{"role": "user", "content": prompt}]
+        instruction = style_instruction(style)
+        if instruction:
+            messages.insert(0, {"role": "system", "content": instruction})
 
         if self.settings.openai_max_retries <= 0:
             raise ValueError("openai_max_retries must be greater than 0")
@@ -111,11 +225,10 @@ class OpenAISummarizer:
         try:
             start_time = time.monotonic()
             response = await self.client.chat.completions.create(
-                model=self.settings.openai_model,
-                messages=[
-                    {"role": "user", "content": prompt},
-                ],
+                model=model,
+                messages=messages,
                 timeout=self.settings.openai_timeout_seconds,
+                **(params or self._generation_params()).request_kwargs(),
             )
             elapsed = time.monotonic() - start_time
 
@@ -127,7 +240,7 @@ class OpenAISummarizer:
                 raise RuntimeError("no OpenAI response")
 
             content = response.choices[0].message.content
-            if not content:
+            if not content or not content.strip():
                 logger.warning(
                     "OpenAI returned empty response",
                     extra={
@@ -136,18 +249,38 @@ class OpenAISummarizer:
                         "choices": getattr(response, "choices", None),
                     },
                 )
-                raise RuntimeError("empty OpenAI response")
+                raise EmptySummaryError("empty OpenAI response")
+
+            usage = LLMUsage.from_completion(response, model)
+            if usage:
+                self.usage_totals.add(usage)
 
             logger.info(
                 "Summary received",
                 extra={
                     "locale": locale,
-                    "model": self.settings.openai_model,
+                    "model": usage.model if usage else model,
                     "elapsed_ms": int(elapsed * 1000),
                     "content_length": len(content),
+                    "prompt_tokens": usage.prompt_tokens if usage else None,
+                    "completion_tokens": usage.completion_tokens if usage else None,
+                    "total_tokens": usage.total_tokens if usage else None,
                 },
             )
+            if self.settings.feature_flags.summary_cleanup:
+                content = clean_summary(content)
+            if is_refusal(content):
+                logger.warning("OpenAI refused to summarize", extra={"model": model, "content": content})
+                raise SummaryRefusedError(f"model refused to summarize: {content}")
             return content
+        except SummaryUnavailableError:
+            raise
+        except APITimeoutError as exc:
+            logger.warning(
+                "OpenAI summarization timed out",
+                extra={"timeout_seconds": self.settings.openai_timeout_seconds, "error": str(exc)},
+            )
+            raise SummarizationTimeoutError(f"summarization timed out after {self.settings.openai_timeout_seconds}s") from exc
         except Exception as exc:
             logger.warning(
                 "OpenAI summarization attempt failed",
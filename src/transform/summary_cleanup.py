@@ -0,0 +1,66 @@
+"""
+LLM summary post-processing.
+
+Models sometimes wrap the summary in chat boilerplate ("Here is a summary:"),
+a Markdown code fence, or a redundant "Summary" heading. Each cleanup rule removes
+one such pattern; rules run in order and are kept small so new ones are easy to add.
+"""
+
+from __future__ import annotations
+
+import re
+from collections.abc import Callable, Sequence
+
+CleanupRule = Callable[[str], str]
+
+# "Sure! Here is a concise summary of the video:" on the first line
+_PREAMBLE_RE = re.compile(
+    r"\A\s*(?:(?:sure|certainly|of course|okay|ok)[!,.]?\s*)?here(?:'s|’s| is| are)\b[^\n]*?"
+    r"\b(?:summary|summarization|overview|key points)\b[^\n]*?:[ \t]*(?:\n|\Z)",
+    re.IGNORECASE,
+)
+# The whole response inside a single ``` fence, optionally tagged with a language
+_FENCE_RE = re.compile(r"\A\s*```[\w-]*[ \t]*\n(.*?)\n[ \t]*```\s*\Z", re.DOTALL)
+# A first line that only says "Summary", e.g. "# Summary" or "**Summary:**"
+_HEADING_RE = re.compile(r"\A\s*(?:#{1,6}[ \t]*)?(?:\*\*)?summary:?(?:\*\*)?:?[ \t]*\n", re.IGNORECASE)
+
+
+def strip_preamble(text: str) -> str:
+    """Remove an introductory "Here is a summary:" line."""
+    return _PREAMBLE_RE.sub("", text, count=1).strip()
+
+
+def unwrap_code_fence(text: str) -> str:
+    """Return the content of a response that is entirely one fenced code block."""
+    match = _FENCE_RE.match(text)
+    return match.group(1).strip() if match else text.strip()
+
+
+def strip_summary_heading(text: str) -> str:
+    """Remove a leading heading that only says "Summary"."""
+    return _HEADING_RE.sub("", text, count=1).strip()
+
+
+SUMMARY_CLEANUP_RULES: tuple[CleanupRule, ...] = (
+    str.strip,
+    strip_preamble,
+    unwrap_code_fence,
+    strip_summary_heading,
+)
+
+
+def clean_summary(text: str, rules: Sequence[CleanupRule] = SUMMARY_CLEANUP_RULES) -> str:
+    """
+    Apply the cleanup rules to a summary returned by the LLM.
+
+    Args:
+        text: Raw model output.
+        rules: Rules to apply, in order.
+
+    Returns:
+        Cleaned summary, or the original text if cleaning would leave nothing.
+    """
+    cleaned = text
+    for rule in rules:
+        cleaned = rule(cleaned)
+    return cleaned or text
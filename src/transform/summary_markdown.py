@@ -0,0 +1,60 @@
+"""
+Markdown rendering of summaries.
+
+Renders a summary together with the metadata of its video as a standalone
+Markdown document, so every client sends the same structure:
+
+    # [Title](https://video.url)
+
+    *Uploader*
+
+    ![Title](https://thumbnail.url)
+
+    Summary body
+"""
+
+from __future__ import annotations
+
+import re
+
+from ..load.video_loader import VideoTranscript
+
+# Characters with a meaning inside Markdown link text or emphasis
+_MARKDOWN_SPECIAL_RE = re.compile(r"([\\\[\]*_`])")
+# Characters that would end a Markdown link destination early
+_URL_UNSAFE = {" ": "%20", "(": "%28", ")": "%29", "<": "%3C", ">": "%3E"}
+_DEFAULT_TITLE = "Video"
+
+
+def render_summary_markdown(video_url: str, transcript: VideoTranscript, summary: str) -> str:
+    """
+    Render a summary as a Markdown document headed by the video metadata.
+
+    The uploader and thumbnail lines are left out when unknown.
+
+    Args:
+        video_url: The summarized video URL.
+        transcript: Transcript of the video, providing its title, uploader and thumbnail.
+        summary: The summary body, already in Markdown.
+
+    Returns:
+        The Markdown document, ending with a newline.
+    """
+    title = escape_markdown(transcript.title.strip() or _DEFAULT_TITLE)
+    blocks = [f"# [{title}]({escape_url(video_url)})"]
+    if uploader := transcript.uploader.strip():
+        blocks.append(f"*{escape_markdown(uploader)}*")
+    if thumbnail := transcript.thumbnail.strip():
+        blocks.append(f"![{title}]({escape_url(thumbnail)})")
+    blocks.append(summary.strip())
+    return "\n\n".join(block for block in blocks if block) + "\n"
+
+
+def escape_markdown(text: str) -> str:
+    """Escape characters that Markdown would read as link or emphasis syntax."""
+    return _MARKDOWN_SPECIAL_RE.sub(r"\\\1", text)
+
+
+def escape_url(url: str) -> str:
+    """Percent-encode characters that would end a Markdown link destination."""
+    return "".join(_URL_UNSAFE.get(char, char) for char in url.strip())
@@ -0,0 +1,47 @@
+"""
+Summarization prompt and summary notes.
+
+Builds the prompt sent to the LLM in the user's language, and the note appended to
+a summary when the transcript had to be shortened first.
+"""
+
+from __future__ import annotations
+
+from ..localization import DEFAULT_LOCALE, normalize_locale, supported_locales, translate
+from .transcript_limit import TranscriptLimitPolicy
+
+
+def build_prompt(text: str, locale: str) -> str:
+    """
+    Build the summarization prompt in the user's language.
+
+    Locales without a translated prompt get the English prompt, which names the
+    requested output language instead of asking for an English summary.
+
+    Args:
+        text: Text to summarize.
+        locale: Language the summary is written in.
+
+    Returns:
+        The prompt with the text embedded.
+    """
+    if normalize_locale(locale) in supported_locales():
+        return translate("openai.prompt", locale=locale, text=text)
+    return translate("openai.prompt_for_language", locale=DEFAULT_LOCALE, text=text, language=locale)
+
+
+def with_limit_note(summary: str, policy: TranscriptLimitPolicy | None, locale: str) -> str:
+    """
+    Append a note saying the summary covers only part of the transcript.
+
+    Args:
+        summary: Summary of the shortened transcript.
+        policy: Policy the transcript was shortened with, or None if it was not.
+        locale: Language of the note.
+
+    Returns:
+        The summary, with the note if the transcript was shortened.
+    """
+    if policy is None:
+        return summary
+    return f"{summary}\n\n{translate(f'openai.limit_note.{policy.value}', locale=locale)}"
@@ -0,0 +1,39 @@
+"""
+Summary style options.
+
+Defines the output styles a user can request for a summary and
+the extra instruction given to the LLM for each of them.
+"""
+
+from __future__ import annotations
+
+from enum import Enum
+
+
+class SummaryStyle(str, Enum):
+    """Supported summary output styles."""
+
+    DEFAULT = "default"
+    SHORT = "short"
+    DETAILED = "detailed"
+    BULLETS = "bullets"
+
+
+_STYLE_INSTRUCTIONS: dict[SummaryStyle, str] = {
+    SummaryStyle.SHORT: "Keep the summary very short: no more than five sentences covering only the main idea.",
+    SummaryStyle.DETAILED: "Write a detailed summary that covers every important point, example, and conclusion.",
+    SummaryStyle.BULLETS: "Format the whole summary as a bulleted list of key points, one idea per bullet.",
+}
+
+
+def style_instruction(style: SummaryStyle) -> str | None:
+    """
+    Get the LLM instruction for a summary style.
+
+    Args:
+        style: Requested summary style.
+
+    Returns:
+        Instruction text, or None for the default style.
+    """
+    return _STYLE_INSTRUCTIONS.get(style)
@@ -0,0 +1,63 @@
+"""
+Token count estimates.
+
+Estimates how many tokens a text takes up in a model's context before it is sent,
+so the size of a request can be judged and logged up front. Uses the model's
+tiktoken encoding when tiktoken is installed and knows the model, otherwise
+assumes ``CHARS_PER_TOKEN`` characters per token.
+
+tiktoken downloads an encoding on first use, so the estimate blocks and should be
+computed off the event loop.
+"""
+
+from __future__ import annotations
+
+import logging
+import math
+from functools import lru_cache
+from typing import Any
+
+logger = logging.getLogger(__name__)
+
+# Rough average for English text with OpenAI tokenizers; other scripts take more tokens per character.
+CHARS_PER_TOKEN = 4
+
+
+def estimate_tokens(text: str, model: str) -> int:
+    """
+    Estimate the number of tokens in a text.
+
+    Args:
+        text: Text to measure.
+        model: Model the text is meant for, e.g. ``gpt-4o``.
+
+    Returns:
+        The exact token count if the model's tokenizer is available, otherwise an estimate based on the text length.
+    """
+    if not text:
+        return 0
+    encoding = _encoding_for_model(model)
+    if encoding is None:
+        return math.ceil(len(text) / CHARS_PER_TOKEN)
+    return len(encoding.encode(text, disallowed_special=()))
+
+
+@lru_cache(maxsize=16)
+def _encoding_for_model(model: str) -> Any | None:
+    """
+    Returns the tiktoken encoding of a model, or None if it is not available.
+
+    That is when tiktoken is not installed, does not know the model, or cannot download
+    the encoding. The result is cached, so a failed download is not retried on every request.
+    """
+    try:
+        import tiktoken  # noqa: PLC0415
+    except ImportError:
+        return None
+    try:
+        return tiktoken.encoding_for_model(model)
+    except KeyError:
+        return None
+    except Exception as exc:
+        logger.warning("Tokenizer unavailable, estimating tokens from text length", extra={"model": model, "error": str(exc)})
+        return None
@@ -0,0 +1,84 @@
+"""
+Transcript length limit.
+
+Multi-hour streams produce transcripts that are slow and expensive to summarize.
+Transcripts longer than the limit are reduced before they reach the LLM:
+
+- ``truncate`` keeps the beginning of the transcript;
+- ``sample`` keeps evenly spaced excerpts, so the summary covers the whole video.
+
+Kept text ends at a word boundary where possible, and every gap is marked with ``OMISSION_MARKER``.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from enum import Enum
+
+OMISSION_MARKER = "\n[…]\n"
+# Number of excerpts the sample policy takes from an over-limit transcript
+SAMPLE_EXCERPTS = 8
+
+
+class TranscriptLimitPolicy(str, Enum):
+    """How an over-limit transcript is reduced."""
+
+    TRUNCATE = "truncate"
+    SAMPLE = "sample"
+
+
+@dataclass(frozen=True)
+class LimitedTranscript:
+    """
+    Transcript text prepared for summarization.
+
+    Attributes:
+        text: Text to summarize.
+        applied_policy: Policy used to shorten the text, or None if it was within the limit.
+    """
+
+    text: str
+    applied_policy: TranscriptLimitPolicy | None = None
+
+
+def limit_transcript(text: str, max_chars: int, policy: TranscriptLimitPolicy) -> LimitedTranscript:
+    """
+    Shorten a transcript to at most ``max_chars`` characters.
+
+    Args:
+        text: Transcript text.
+        max_chars: Maximum length; 0 disables the limit.
+        policy: How to shorten an over-limit transcript.
+
+    Returns:
+        The transcript unchanged if within the limit, otherwise the shortened text and the policy used.
+    """
+    if max_chars <= 0 or len(text) <= max_chars:
+        return LimitedTranscript(text)
+    if policy is TranscriptLimitPolicy.SAMPLE:
+        return LimitedTranscript(_sample(text, max_chars), policy)
+    return LimitedTranscript(_truncate(text, max_chars), policy)
+
+
+def _truncate(text: str, max_chars: int) -> str:
+    """Keep the beginning of the text and mark the cut."""
+    return _cut(text, 0, max(max_chars - len(OMISSION_MARKER), 0)) + OMISSION_MARKER.rstrip()
+
+
+def _sample(text: str, max_chars: int) -> str:
+    """Keep ``SAMPLE_EXCERPTS`` evenly spaced excerpts joined by omission markers."""
+    excerpt_length = (max_chars - len(OMISSION_MARKER) * (SAMPLE_EXCERPTS + 1)) // SAMPLE_EXCERPTS
+    if excerpt_length <= 0:
+        return _truncate(text, max_chars)
+
+    step = len(text) // SAMPLE_EXCERPTS
+    excerpts = [_cut(text, index * step, excerpt_length) for index in range(SAMPLE_EXCERPTS)]
+    return OMISSION_MARKER.join(excerpt for excerpt in excerpts if excerpt) + OMISSION_MARKER.rstrip()
+
+
+def _cut(text: str, start: int, length: int) -> str:
+    """Return up to ``length`` characters from ``start``, ending at a word boundary when possible."""
+    excerpt = text[start : start + length]
+    if start + length < len(text) and (boundary := max(excerpt.rfind(" "), excerpt.rfind("\n"))) > 0:
+        excerpt = excerpt[:boundary]
+    return excerpt.strip()
@@ -0,0 +1,64 @@
+"""
+LLM token usage accounting.
+
+Extracts token counts from chat completion responses and
+aggregates them so the cost of summarization can be tracked.
+"""
+
+from __future__ import annotations
+
+from dataclasses import dataclass
+from typing import Any
+
+
+@dataclass(frozen=True)
+class LLMUsage:
+    """Token usage of a single LLM request."""
+
+    model: str
+    prompt_tokens: int
+    completion_tokens: int
+    total_tokens: int
+
+    @classmethod
+    def from_completion(cls, response: Any, default_model: str) -> LLMUsage | None:
+        """
+        Read token usage from a chat completion response.
+
+        Args:
+            response: Chat completion returned by the OpenAI client.
+            default_model: Model to report if the response does not name one.
+
+        Returns:
+            Usage of the request, or None if the endpoint did not report it.
+        """
+        usage = getattr(response, "usage", None)
+        if usage is None:
+            return None
+
+        prompt_tokens = int(getattr(usage, "prompt_tokens", 0) or 0)
+        completion_tokens = int(getattr(usage, "completion_tokens", 0) or 0)
+        total_tokens = int(getattr(usage, "total_tokens", 0) or prompt_tokens + completion_tokens)
+        return cls(
+            model=getattr(response, "model", None) or default_model,
+            prompt_tokens=prompt_tokens,
+            completion_tokens=completion_tokens,
+            total_tokens=total_tokens,
+        )
+
+
+@dataclass
+class UsageTotals:
+    """Token usage accumulated across LLM requests."""
+
+    requests: int = 0
+    prompt_tokens: int = 0
+    completion_tokens: int = 0
+    total_tokens: int = 0
+
+    def add(self, usage: LLMUsage) -> None:
+        """Add the usage of one request to the totals."""
+        self.requests += 1
+        self.prompt_tokens += usage.prompt_tokens
+        self.completion_tokens += usage.completion_tokens
+        self.total_tokens += usage.total_tokens
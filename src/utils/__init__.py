@@ -6,12 +6,13 @@ Provides helper functions for text processing and formatting.
 
 from .compression import CompressionMethod, compress, decompress
 from .markdown import markdown_to_telegram_html
-from .text import to_lexical_chunks
+from .text import format_duration, to_lexical_chunks
 
 __all__ = [
     "CompressionMethod",
     "compress",
     "decompress",
+    "format_duration",
     "markdown_to_telegram_html",
     "to_lexical_chunks",
 ]
@@ -3,6 +3,7 @@ Markdown to Telegram HTML converter.
 
 Converts Markdown syntax to Telegram-compatible HTML format.
 Handles unsupported tags by converting them to safe alternatives.
+Converts that HTML back to plain text for when Telegram rejects it.
 """
 
 import re
@@ -59,3 +60,24 @@ def markdown_to_telegram_html(md_text: str) -> str:
     # Replace multiple consecutive newlines with a single pair
     result = re.sub(r"\n{3,}", "\n\n", result)
     return result
+
+
+def telegram_html_to_text(html_text: str) -> str:
+    """
+    Convert Telegram HTML to plain text, for sending without a parse mode.
+
+    Tags are dropped with their content kept and entities are unescaped; a link
+    keeps its URL after the text when the two differ.
+
+    Args:
+        html_text: Telegram HTML, possibly malformed.
+
+    Returns:
+        Plain text.
+    """
+    soup = BeautifulSoup(html_text, "html.parser")
+    for link in soup.find_all("a"):
+        href = link.get("href")
+        text = link.get_text()
+        link.replace_with(f"{text} ({href})" if href and href != text else text)
+    return soup.get_text()
@@ -2,7 +2,8 @@
 Text processing utilities.
 
 Provides functions for chunking text into smaller segments
-at natural breakpoints (paragraphs, sentences, words).
+at natural breakpoints (paragraphs, sentences, words), and for
+formatting durations.
 """
 
 from __future__ import annotations
@@ -92,3 +93,12 @@ def _find_natural_breakpoint(text: str, left: int, right: int) -> int:
             return left + word_idx + 1
 
     return right
+
+
+def format_duration(seconds: float | None) -> str:
+    """Format a length or position in a video as ``H:MM:SS`` or ``M:SS``, or a dash when unknown."""
+    if seconds is None:
+        return "—"
+    hours, rest = divmod(int(seconds), 3600)
+    minutes, secs = divmod(rest, 60)
+    return f"{hours}:{minutes:02}:{secs:02}" if hours else f"{minutes}:{secs:02}"
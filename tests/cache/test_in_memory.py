@@ -2,6 +2,7 @@ import asyncio
 
 import pytest
 from src.cache import InMemoryCacheProvider
+from src.cache.in_memory import MARKER_SWEEP_INTERVAL_SECONDS
 
 
 @pytest.fixture
@@ -76,3 +77,29 @@ async def test_in_memory_transcript_expiration(provider: InMemoryCacheProvider)
     await asyncio.sleep(1.1)
 
     assert await provider.get_dict("transcript:hash123") is None
+
+
+@pytest.mark.asyncio
+async def test_in_memory_put_if_absent() -> None:
+    now = [0.0]
+    provider = InMemoryCacheProvider(clock=lambda: now[0])
+
+    assert await provider.put_if_absent("processed:1:2", 10)
+    assert not await provider.put_if_absent("processed:1:2", 10)
+
+    now[0] = 11.0
+    assert await provider.put_if_absent("processed:1:2", 10)
+
+
+@pytest.mark.asyncio
+async def test_in_memory_put_if_absent_removes_expired_markers() -> None:
+    now = [0.0]
+    provider = InMemoryCacheProvider(clock=lambda: now[0])
+    for message_id in range(100):
+        await provider.put_if_absent(f"processed:1:{message_id}", 10)
+    await provider.put_if_absent("update:1", MARKER_SWEEP_INTERVAL_SECONDS * 2)
+
+    now[0] = MARKER_SWEEP_INTERVAL_SECONDS
+    await provider.put_if_absent("processed:1:100", 10)
+
+    assert set(provider._markers) == {"update:1", "processed:1:100"}
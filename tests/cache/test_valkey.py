@@ -155,3 +155,27 @@ async def test_valkey_set_get_transcript_with_compression(provider_with_compress
         data = await provider_with_compression.get_dict("transcript:hash123")
         assert data == original_transcript
         mock_client.get.assert_called_with("transcript:hash123:gzip")
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(("set_result", "expected_recorded"), [(True, True), (None, False)])
+async def test_valkey_put_if_absent(provider: ValkeyProvider, set_result: bool | None, expected_recorded: bool) -> None:
+    with patch("src.cache.valkey.Valkey") as mock_valkey:
+        mock_client = AsyncMock()
+        mock_client.set.return_value = set_result
+        mock_valkey.from_url.return_value = mock_client
+
+        recorded = await provider.put_if_absent("processed:1:2", 600)
+
+        assert recorded is expected_recorded
+        mock_client.set.assert_called_once_with("processed:1:2", b"1", ex=600, nx=True)
+
+
+@pytest.mark.asyncio
+async def test_valkey_put_if_absent_fails_open(provider: ValkeyProvider) -> None:
+    with patch("src.cache.valkey.Valkey") as mock_valkey:
+        mock_client = AsyncMock()
+        mock_client.set.side_effect = ConnectionError("down")
+        mock_valkey.from_url.return_value = mock_client
+
+        assert await provider.put_if_absent("processed:1:2", 600)
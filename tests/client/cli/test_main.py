@@ -0,0 +1,130 @@
+import io
+import json
+from unittest.mock import AsyncMock, MagicMock, patch
+
+import pytest
+from src.client.cli.main import collect_urls, main, parse_args, summarize_urls
+from src.load.video_loader import VideoTranscript
+from src.transform.summary_style import SummaryStyle
+
+
+def build_transcript(title: str = "Test Video") -> VideoTranscript:
+    return VideoTranscript(id="id", language="en", uploader="uploader", title=title, thumbnail="", transcript="transcript text")
+
+
+def test_parse_args_defaults() -> None:
+    args = parse_args(["--url", "https://youtu.be/dQw4w9WgXcQ"])
+
+    assert args.url == ["https://youtu.be/dQw4w9WgXcQ"]
+    assert args.lang == "en"
+    assert args.style is None
+    assert args.model is None
+    assert args.json is False
+
+
+def test_parse_args_rejects_unknown_style() -> None:
+    with pytest.raises(SystemExit):
+        parse_args(["--style", "haiku"])
+
+
+def test_collect_urls_prefers_url_arguments() -> None:
+    args = parse_args(["--url", "https://youtu.be/dQw4w9WgXcQ"])
+
+    assert collect_urls(args, io.StringIO("https://youtu.be/abcdefghijk")) == ["https://youtu.be/dQw4w9WgXcQ"]
+
+
+def test_collect_urls_reads_stdin() -> None:
+    args = parse_args([])
+    stdin = io.StringIO("https://youtu.be/dQw4w9WgXcQ\nnot a link\nhttps://vkvideo.ru/video-123_456\nhttps://youtu.be/dQw4w9WgXcQ\n")
+
+    assert collect_urls(args, stdin) == ["https://www.youtube.com/watch?v=dQw4w9WgXcQ", "https://vkvideo.ru/video-123_456"]
+
+
+@pytest.mark.asyncio
+async def test_summarize_urls_prints_markdown() -> None:
+    loader = AsyncMock()
+    loader.load.return_value = build_transcript()
+    summarizer = AsyncMock()
+    summarizer.summarize.return_value = "The summary."
+    out = io.StringIO()
+
+    args = parse_args(["--lang", "de", "--style", "bullets"])
+    exit_code = await summarize_urls(args, ["https://youtu.be/dQw4w9WgXcQ"], loader, summarizer, out)
+
+    assert exit_code == 0
+    loader.load.assert_called_once_with("https://youtu.be/dQw4w9WgXcQ")
+    summarizer.summarize.assert_called_once_with("transcript text", "de", SummaryStyle.BULLETS, refresh=False)
+    assert out.getvalue() == "# Test Video\n\nhttps://youtu.be/dQw4w9WgXcQ\n\nThe summary.\n\n"
+
+
+@pytest.mark.asyncio
+async def test_summarize_urls_prints_json() -> None:
+    loader = AsyncMock()
+    loader.load.return_value = build_transcript()
+    summarizer = AsyncMock()
+    summarizer.summarize.return_value = "The summary."
+    summarizer.default_style = MagicMock(return_value=SummaryStyle.DETAILED)
+    out = io.StringIO()
+
+    exit_code = await summarize_urls(parse_args(["--json"]), ["https://youtu.be/dQw4w9WgXcQ"], loader, summarizer, out)
+
+    assert exit_code == 0
+    summarizer.default_style.assert_called_once_with("en")
+    summarizer.summarize.assert_called_once_with("transcript text", "en", SummaryStyle.DETAILED, refresh=False)
+    assert json.loads(out.getvalue()) == {
+        "url": "https://youtu.be/dQw4w9WgXcQ",
+        "title": "Test Video",
+        "language": "en",
+        "style": "detailed",
+        "summary": "The summary.",
+    }
+
+
+@pytest.mark.asyncio
+async def test_summarize_urls_continues_after_failure() -> None:
+    loader = AsyncMock()
+    loader.load.side_effect = [RuntimeError("no subtitles"), build_transcript("Second")]
+    summarizer = AsyncMock()
+    summarizer.summarize.return_value = "The summary."
+    summarizer.default_style = MagicMock(return_value=SummaryStyle.DEFAULT)
+    out = io.StringIO()
+
+    urls = ["https://youtu.be/dQw4w9WgXcQ", "https://youtu.be/abcdefghijk"]
+    exit_code = await summarize_urls(parse_args([]), urls, loader, summarizer, out)
+
+    assert exit_code == 1
+    summarizer.summarize.assert_called_once()
+    assert out.getvalue().startswith("# Second")
+
+
+@pytest.mark.asyncio
+async def test_main_overrides_model() -> None:
+    settings = MagicMock()
+    with (
+        patch("src.client.cli.main.Settings.from_env", return_value=settings) as mock_from_env,
+        patch("src.client.cli.main.dataclasses.replace", return_value="overridden") as mock_replace,
+        patch("src.client.cli.main.ProviderVideoLoader") as mock_loader_class,
+        patch("src.client.cli.main.OpenAISummarizer") as mock_summarizer_class,
+        patch("src.client.cli.main.summarize_urls", new_callable=AsyncMock, return_value=0) as mock_summarize_urls,
+    ):
+        exit_code = await main(["--url", "https://youtu.be/dQw4w9WgXcQ", "--model", "gpt-4o"])
+
+    assert exit_code == 0
+    mock_from_env.assert_called_once_with(require_telegram=False)
+    mock_replace.assert_called_once_with(settings, openai_model="gpt-4o")
+    mock_loader_class.assert_called_once_with("overridden")
+    mock_summarizer_class.assert_called_once_with("overridden")
+    mock_summarize_urls.assert_called_once()
+
+
+@pytest.mark.asyncio
+async def test_main_without_urls() -> None:
+    with (
+        patch("src.client.cli.main.sys.stdin", io.StringIO("no links here")),
+        patch("src.client.cli.main.Settings.from_env") as mock_from_env,
+    ):
+        exit_code = await main([])
+
+    expected_exit_code = 2
+    assert exit_code == expected_exit_code
+    mock_from_env.assert_not_called()
@@ -0,0 +1,72 @@
+from typing import Any
+from unittest.mock import AsyncMock, MagicMock
+
+import pytest
+from aiogram.types import CallbackQuery, Message, User
+from src.config import FeatureFlags, Settings
+
+
+@pytest.fixture
+def mock_settings() -> Settings:
+    settings = MagicMock(spec=Settings)
+    settings.rate_limit_window_seconds = 10
+    settings.telegram_bot_token = "123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11"
+    settings.openai_base_url = "https://api.openai.com/v1/"
+    settings.openai_api_key = "test_key"
+    settings.openai_model = "gpt-3.5-turbo"
+    settings.openai_models = ("gpt-4o",)
+    settings.valkey_url = None
+    settings.cache_compression_method = "gzip"
+    settings.yt_dlp_additional_options = ()
+    settings.max_telegram_message_length = 4000
+    settings.send_as_file_threshold = 0
+    settings.pasted_text_min_length = 1000
+    settings.min_transcript_chars = 0
+    settings.summary_header_key = "telegram.response.title"
+    settings.progress_style = "replace"
+    settings.telegram_admin_ids = (123,)
+    settings.feature_flags = FeatureFlags()
+    return settings
+
+
+@pytest.fixture
+def mock_deps(mock_settings: Settings) -> Any:
+    class Deps:
+        loader = AsyncMock()
+        summarizer = AsyncMock()
+        rate_limiter = AsyncMock()
+        source_store = AsyncMock()
+        language_preferences = AsyncMock()
+        settings = mock_settings
+
+    deps = Deps()
+    deps.source_store.remember.return_value = "abc123"
+    deps.language_preferences.get.return_value = None
+    deps.language_preferences.get_chat.return_value = None
+    return deps
+
+
+@pytest.fixture
+def mock_message() -> MagicMock:
+    user = MagicMock(spec=User)
+    user.id = 123
+    user.username = "testuser"
+    user.language_code = "en"
+    user.is_bot = False
+
+    message = AsyncMock(spec=Message)
+    message.message_id = 1
+    message.from_user = user
+    message.text = "https://youtube.com/watch?v=123"
+    message.caption = None
+    message.reply = AsyncMock()
+    return message
+
+
+@pytest.fixture
+def mock_callback_query(mock_message: MagicMock) -> MagicMock:
+    query = AsyncMock(spec=CallbackQuery)
+    query.from_user = mock_message.from_user
+    query.message = mock_message
+    query.answer = AsyncMock()
+    return query
@@ -0,0 +1,191 @@
+from unittest.mock import AsyncMock, MagicMock, patch
+
+import pytest
+from src.cache import InMemoryCacheProvider
+from src.client.telegram.handlers.callbacks import handle_pick_callback, handle_style_callback
+from src.client.telegram.handlers.style_keyboard import STYLE_BUTTONS, SummarySourceStore, SummaryStyleCallback, build_style_keyboard
+from src.client.telegram.handlers.video_picker import MAX_PICKER_VIDEOS, PickVideoCallback, build_video_picker
+from src.load.errors import VideoPrivateError
+from src.load.video_loader import VideoTranscript
+from src.transform.summary_style import SummaryStyle
+
+
+@pytest.mark.asyncio
+async def test_bot_style_callback_resummarizes(mock_deps: MagicMock, mock_callback_query: MagicMock) -> None:
+    transcript = VideoTranscript(id="123", language="en", uploader="test", title="Test Video", thumbnail="", transcript="Test transcript")
+    processing_msg_mock = AsyncMock()
+    mock_callback_query.message.reply.return_value = processing_msg_mock
+    callback_data = SummaryStyleCallback(style=SummaryStyle.BULLETS, token="abc123")
+
+    with (
+        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
+        patch.object(mock_deps.source_store, "resolve", return_value="https://youtube.com/watch?v=123") as mock_resolve,
+        patch.object(mock_deps.loader, "load", return_value=transcript) as mock_load,
+        patch.object(mock_deps.summarizer, "summarize", return_value="- Point") as mock_summarize,
+        patch("src.client.telegram.handlers.callbacks.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.summary_reply.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.style_keyboard.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_style_callback(
+            mock_callback_query,
+            callback_data,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    mock_resolve.assert_called_once_with("abc123")
+    mock_load.assert_called_once_with("https://youtube.com/watch?v=123")
+    mock_summarize.assert_called_once_with("Test transcript", "en", SummaryStyle.BULLETS, refresh=False)
+    mock_callback_query.answer.assert_called_once_with()
+    # Progress message plus the restyled summary
+    expected_calls = 2
+    assert mock_callback_query.message.reply.call_count == expected_calls
+    processing_msg_mock.delete.assert_called_once()
+
+
+@pytest.mark.asyncio
+async def test_bot_style_callback_reports_load_error(mock_deps: MagicMock, mock_callback_query: MagicMock) -> None:
+    processing_msg_mock = AsyncMock()
+    mock_callback_query.message.reply.return_value = processing_msg_mock
+    callback_data = SummaryStyleCallback(style=SummaryStyle.BULLETS, token="abc123")
+
+    with (
+        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
+        patch.object(mock_deps.source_store, "resolve", return_value="https://youtube.com/watch?v=123"),
+        patch.object(mock_deps.loader, "load", side_effect=VideoPrivateError("Private video")),
+        patch.object(mock_deps.summarizer, "summarize") as mock_summarize,
+        patch("src.client.telegram.handlers.callbacks.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_style_callback(
+            mock_callback_query,
+            callback_data,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    processing_msg_mock.edit_text.assert_called_once_with("telegram.error.video_private")
+    mock_summarize.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_bot_style_callback_expired_source(mock_deps: MagicMock, mock_callback_query: MagicMock) -> None:
+    callback_data = SummaryStyleCallback(style=SummaryStyle.SHORT, token="abc123")
+
+    with (
+        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
+        patch.object(mock_deps.source_store, "resolve", return_value=None),
+        patch("src.client.telegram.handlers.callbacks.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_style_callback(
+            mock_callback_query,
+            callback_data,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    mock_callback_query.answer.assert_called_once_with("telegram.error.style_expired", show_alert=True)
+    mock_deps.loader.load.assert_not_called()
+    mock_callback_query.message.reply.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_bot_pick_callback_summarizes_picked_video(mock_deps: MagicMock, mock_callback_query: MagicMock) -> None:
+    user_message = mock_callback_query.message.reply_to_message
+    callback_data = PickVideoCallback(token="abc123")
+
+    with (
+        patch.object(mock_deps.source_store, "resolve", return_value="https://vkvideo.ru/video-123_456"),
+        patch("src.client.telegram.handlers.callbacks.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.callbacks.summarize_video_url", new_callable=AsyncMock) as mock_summarize_video_url,
+    ):
+        await handle_pick_callback(
+            mock_callback_query,
+            callback_data,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    mock_callback_query.answer.assert_called_once_with()
+    mock_callback_query.message.edit_text.assert_called_once_with("telegram.progress.processing")
+    mock_summarize_video_url.assert_called_once_with(
+        user_message,
+        mock_callback_query.message,
+        "https://vkvideo.ru/video-123_456",
+        "en",
+        mock_deps.loader,
+        mock_deps.summarizer,
+        mock_deps.settings,
+        mock_deps.source_store,
+    )
+
+
+@pytest.mark.asyncio
+async def test_bot_pick_callback_expired_choice(mock_deps: MagicMock, mock_callback_query: MagicMock) -> None:
+    with (
+        patch.object(mock_deps.source_store, "resolve", return_value=None),
+        patch("src.client.telegram.handlers.callbacks.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.callbacks.summarize_video_url", new_callable=AsyncMock) as mock_summarize_video_url,
+    ):
+        await handle_pick_callback(
+            mock_callback_query,
+            PickVideoCallback(token="abc123"),
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    mock_callback_query.answer.assert_called_once_with("telegram.error.choice_expired", show_alert=True)
+    mock_summarize_video_url.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_build_video_picker_caps_the_number_of_videos() -> None:
+    source_store = SummarySourceStore(InMemoryCacheProvider(), ttl_seconds=60)
+    urls = [f"https://vkvideo.ru/video-1_{i}" for i in range(MAX_PICKER_VIDEOS + 2)]
+
+    markup = await build_video_picker(urls, source_store)
+
+    buttons = [button for row in markup.inline_keyboard for button in row]
+    assert len(buttons) == MAX_PICKER_VIDEOS
+    assert buttons[0].text == "1. VK Video: video-1_0"
+    token = PickVideoCallback.unpack(str(buttons[0].callback_data)).token
+    assert await source_store.resolve(token) == urls[0]
+
+
+def test_build_style_keyboard_maps_buttons_to_styles() -> None:
+    with patch("src.client.telegram.handlers.style_keyboard.translate", side_effect=lambda key, **kw: key):
+        markup = build_style_keyboard("abc123", "en")
+
+    buttons = [button for row in markup.inline_keyboard for button in row]
+    assert [button.text for button in buttons] == [f"telegram.style.{style.value}" for style in STYLE_BUTTONS]
+    for button, style in zip(buttons, STYLE_BUTTONS, strict=True):
+        callback_data = SummaryStyleCallback.unpack(str(button.callback_data))
+        assert callback_data.style == style
+        assert callback_data.token == "abc123"
+
+
+@pytest.mark.asyncio
+async def test_summary_source_store_round_trip() -> None:
+    store = SummarySourceStore(InMemoryCacheProvider(), ttl_seconds=60)
+
+    token = await store.remember("https://www.youtube.com/watch?v=dQw4w9WgXcQ")
+
+    assert await store.resolve(token) == "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+    assert await store.resolve("unknown") is None
@@ -0,0 +1,230 @@
+from unittest.mock import ANY, AsyncMock, MagicMock, patch
+
+import pytest
+from aiogram.filters import CommandObject
+from src.client.telegram.handlers.commands import (
+    language_command,
+    model_command,
+    set_chat_language_command,
+    start_command,
+    start_deep_link_command,
+)
+from src.client.telegram.handlers.deep_link import encode_url_payload
+from src.transform.model_selection import ModelSelection
+
+
+@pytest.mark.asyncio
+async def test_bot_start(mock_message: MagicMock) -> None:
+    # Need to mock translate since we aren't loading translations in unit tests by default
+    with patch("src.client.telegram.handlers.commands.translate", return_value="Welcome"):
+        await start_command(mock_message)
+    mock_message.reply.assert_called_once_with("Welcome")
+
+
+@pytest.mark.asyncio
+async def test_bot_start_with_referral(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    with (
+        patch("src.client.telegram.handlers.commands.translate", return_value="Welcome"),
+        patch("src.client.telegram.handlers.commands.summarize_video_url", new_callable=AsyncMock) as mock_summarize_video_url,
+    ):
+        await start_deep_link_command(
+            mock_message,
+            CommandObject(command="start", args="ref_abc"),
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    mock_message.reply.assert_called_once_with("Welcome")
+    mock_summarize_video_url.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_bot_start_with_url_payload(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    processing_msg_mock = AsyncMock()
+    mock_message.reply.return_value = processing_msg_mock
+    mock_deps.rate_limiter.is_limited.return_value = False
+
+    with (
+        patch("src.client.telegram.handlers.commands.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.commands.summarize_video_url", new_callable=AsyncMock) as mock_summarize_video_url,
+    ):
+        await start_deep_link_command(
+            mock_message,
+            CommandObject(command="start", args=encode_url_payload("https://youtu.be/dQw4w9WgXcQ")),
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    assert [call.args[0] for call in mock_message.reply.call_args_list] == ["telegram.welcome.message", "telegram.progress.processing"]
+    mock_summarize_video_url.assert_called_once_with(
+        mock_message,
+        processing_msg_mock,
+        "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+        "en",
+        mock_deps.loader,
+        mock_deps.summarizer,
+        mock_deps.settings,
+        mock_deps.source_store,
+    )
+
+
+@pytest.mark.asyncio
+async def test_bot_start_with_url_payload_rate_limited(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    mock_deps.rate_limiter.is_limited.return_value = True
+
+    with (
+        patch("src.client.telegram.handlers.commands.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.commands.summarize_video_url", new_callable=AsyncMock) as mock_summarize_video_url,
+    ):
+        await start_deep_link_command(
+            mock_message,
+            CommandObject(command="start", args=encode_url_payload("https://youtu.be/dQw4w9WgXcQ")),
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    assert mock_message.reply.call_args_list[-1].args[0] == "telegram.error.rate_limited"
+    mock_summarize_video_url.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_bot_language_command_sets_preference(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    with patch("src.client.telegram.handlers.commands.translate", side_effect=lambda key, **kw: key):
+        await language_command(mock_message, CommandObject(command="language", args="de-DE"), mock_deps.language_preferences)
+
+    mock_deps.language_preferences.set.assert_called_once_with(123, "de")
+    mock_message.reply.assert_called_once_with("telegram.language.updated")
+
+
+@pytest.mark.asyncio
+async def test_bot_language_command_rejects_unknown_locale(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    with patch("src.client.telegram.handlers.commands.translate", side_effect=lambda key, **kw: key):
+        await language_command(mock_message, CommandObject(command="language", args="xx"), mock_deps.language_preferences)
+
+    mock_deps.language_preferences.set.assert_not_called()
+    mock_message.reply.assert_called_once_with("telegram.language.invalid")
+
+
+@pytest.mark.asyncio
+async def test_bot_language_command_without_args_shows_usage(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    with patch("src.client.telegram.handlers.commands.translate", side_effect=lambda key, **kw: key):
+        await language_command(mock_message, CommandObject(command="language"), mock_deps.language_preferences)
+
+    mock_deps.language_preferences.set.assert_not_called()
+    mock_message.reply.assert_called_once_with("telegram.language.usage")
+
+
+def build_bot(status: str) -> AsyncMock:
+    bot = AsyncMock()
+    bot.get_chat_member.return_value = MagicMock(status=status)
+    return bot
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(
+    ("status", "expected_reply"),
+    [("administrator", "telegram.chat_language.updated"), ("member", "telegram.chat_language.admins_only")],
+)
+async def test_setlang_command_only_lets_admins_set_chat_language(
+    mock_deps: MagicMock, mock_message: MagicMock, status: str, expected_reply: str
+) -> None:
+    mock_message.chat = MagicMock(id=-100123, type="supergroup")
+
+    with patch("src.client.telegram.handlers.commands.translate", side_effect=lambda key, **kw: key):
+        await set_chat_language_command(mock_message, CommandObject(command="setlang", args="ja"), build_bot(status), mock_deps.language_preferences)
+
+    mock_message.reply.assert_called_once_with(expected_reply)
+    expected_calls = 1 if status == "administrator" else 0
+    assert mock_deps.language_preferences.set_chat.call_count == expected_calls
+    if expected_calls:
+        mock_deps.language_preferences.set_chat.assert_called_once_with(-100123, "ja")
+
+
+@pytest.mark.asyncio
+async def test_setlang_command_off_clears_chat_language(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    mock_message.chat = MagicMock(id=-100123, type="group")
+
+    with patch("src.client.telegram.handlers.commands.translate", side_effect=lambda key, **kw: key):
+        command = CommandObject(command="setlang", args="OFF")
+        await set_chat_language_command(mock_message, command, build_bot("creator"), mock_deps.language_preferences)
+
+    mock_deps.language_preferences.set_chat.assert_called_once_with(-100123, None)
+    mock_message.reply.assert_called_once_with("telegram.chat_language.cleared")
+
+
+@pytest.mark.asyncio
+async def test_setlang_command_in_private_chat_points_to_language(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    mock_message.chat = MagicMock(id=123, type="private")
+    bot = build_bot("member")
+
+    with patch("src.client.telegram.handlers.commands.translate", side_effect=lambda key, **kw: key):
+        await set_chat_language_command(mock_message, CommandObject(command="setlang", args="ja"), bot, mock_deps.language_preferences)
+
+    mock_deps.language_preferences.set_chat.assert_not_called()
+    bot.get_chat_member.assert_not_called()
+    mock_message.reply.assert_called_once_with("telegram.chat_language.group_only")
+
+
+async def run_model_command(mock_deps: MagicMock, mock_message: MagicMock, args: str | None, active_model: str | None = None) -> AsyncMock:
+    provider = AsyncMock()
+    provider.get.return_value = active_model
+    with patch("src.client.telegram.handlers.commands.translate", side_effect=lambda key, **kw: f"{key} {kw.get('model')} {kw.get('models')}"):
+        await model_command(
+            mock_message,
+            CommandObject(command="model", args=args),
+            mock_deps.settings,
+            ModelSelection(provider, mock_deps.settings),
+            mock_deps.language_preferences,
+        )
+    return provider
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(("active_model", "expected_model"), [(None, "gpt-3.5-turbo"), ("gpt-4o", "gpt-4o"), ("retired-model", "gpt-3.5-turbo")])
+async def test_model_command_lists_models_and_current_one(
+    mock_deps: MagicMock, mock_message: MagicMock, active_model: str | None, expected_model: str
+) -> None:
+    provider = await run_model_command(mock_deps, mock_message, None, active_model)
+
+    mock_message.reply.assert_called_once_with(f"telegram.model.current {expected_model} gpt-3.5-turbo, gpt-4o")
+    provider.put.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_model_command_switches_model(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    provider = await run_model_command(mock_deps, mock_message, " gpt-4o ")
+
+    provider.put.assert_called_once_with("active_model:", "gpt-4o", ANY)
+    mock_message.reply.assert_called_once_with("telegram.model.updated gpt-4o None")
+
+
+@pytest.mark.asyncio
+async def test_model_command_rejects_model_not_allowed(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    provider = await run_model_command(mock_deps, mock_message, "<gpt-5>")
+
+    provider.put.assert_not_called()
+    mock_message.reply.assert_called_once_with("telegram.model.invalid &lt;gpt-5&gt; gpt-3.5-turbo, gpt-4o")
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize("args", [None, "gpt-4o"])
+async def test_model_command_is_admins_only(mock_deps: MagicMock, mock_message: MagicMock, args: str | None) -> None:
+    mock_message.from_user.id = 456
+
+    provider = await run_model_command(mock_deps, mock_message, args)
+
+    mock_message.reply.assert_called_once_with("telegram.model.admins_only None None")
+    provider.get.assert_not_called()
+    provider.put.assert_not_called()
@@ -0,0 +1,32 @@
+import pytest
+from src.client.telegram.handlers.deep_link import StartPayload, encode_url_payload, parse_start_payload
+
+
+@pytest.mark.parametrize(
+    ("payload", "expected"),
+    [
+        (None, StartPayload()),
+        ("", StartPayload()),
+        ("ref_abc", StartPayload(referral="abc")),
+        ("ref_", StartPayload()),
+        (encode_url_payload("https://youtu.be/dQw4w9WgXcQ"), StartPayload(url="https://www.youtube.com/watch?v=dQw4w9WgXcQ")),
+        (encode_url_payload("https://vkvideo.ru/video-123_456"), StartPayload(url="https://vkvideo.ru/video-123_456")),
+        (
+            encode_url_payload("https://youtu.be/dQw4w9WgXcQ https://www.youtube.com/watch?v=dQw4w9WgXcQ"),
+            StartPayload(url="https://www.youtube.com/watch?v=dQw4w9WgXcQ"),
+        ),
+        (encode_url_payload("https://youtu.be/dQw4w9WgXcQ https://vkvideo.ru/video-123_456"), StartPayload()),
+        (encode_url_payload("https://example.com/video"), StartPayload()),
+        ("not-base64!", StartPayload()),
+        ("hello", StartPayload()),
+    ],
+)
+def test_parse_start_payload(payload: str | None, expected: StartPayload) -> None:
+    assert parse_start_payload(payload) == expected
+
+
+def test_encode_url_payload_is_deep_link_safe() -> None:
+    payload = encode_url_payload("https://www.youtube.com/watch?v=dQw4w9WgXcQ")
+
+    assert "=" not in payload
+    assert all(char.isalnum() or char in "-_" for char in payload)
@@ -0,0 +1,103 @@
+import logging
+from unittest.mock import MagicMock, patch
+
+import pytest
+from aiogram.types import ErrorEvent
+from src.client.telegram.handlers.errors import error_handler
+
+
+@pytest.mark.asyncio
+async def test_bot_on_error(mock_message: MagicMock) -> None:
+    event = MagicMock(spec=ErrorEvent)
+    event.exception = Exception("General error")
+    update = MagicMock()
+    update.message = mock_message
+    event.update = update
+
+    with patch("src.client.telegram.handlers.errors.translate", return_value="Error handled"):
+        await error_handler(event)
+    mock_message.reply.assert_called_once_with("Error handled")
+
+
+@pytest.mark.asyncio
+async def test_bot_on_error_logs_update_context(mock_message: MagicMock, caplog: pytest.LogCaptureFixture) -> None:
+    event = MagicMock(spec=ErrorEvent)
+    event.exception = RuntimeError("Handler panic")
+    update = MagicMock()
+    update.update_id = 42
+    update.message = mock_message
+    event.update = update
+
+    with (
+        caplog.at_level(logging.ERROR),
+        patch("src.client.telegram.handlers.errors.translate", return_value="Error handled"),
+    ):
+        await error_handler(event)
+
+    record = next(r for r in caplog.records if r.getMessage() == "Telegram update handling failed")
+    assert record.update_id == 42  # type: ignore[attr-defined]
+    assert record.userID == 123  # type: ignore[attr-defined]
+    assert record.exc_info is not None
+    mock_message.reply.assert_called_once_with("Error handled")
+
+
+@pytest.mark.asyncio
+async def test_bot_on_error_survives_failed_notification(mock_message: MagicMock) -> None:
+    event = MagicMock(spec=ErrorEvent)
+    event.exception = RuntimeError("Handler panic")
+    update = MagicMock()
+    update.message = mock_message
+    event.update = update
+    mock_message.reply.side_effect = Exception("Telegram is down")
+
+    with patch("src.client.telegram.handlers.errors.translate", return_value="Error handled"):
+        await error_handler(event)
+
+    mock_message.reply.assert_called_once_with("Error handled")
+
+
+@pytest.mark.asyncio
+async def test_bot_on_error_answers_callback_query(mock_callback_query: MagicMock, mock_message: MagicMock) -> None:
+    event = MagicMock(spec=ErrorEvent)
+    event.exception = RuntimeError("Handler panic")
+    update = MagicMock()
+    update.message = None
+    update.callback_query = mock_callback_query
+    event.update = update
+
+    with patch("src.client.telegram.handlers.errors.translate", return_value="Error handled"):
+        await error_handler(event)
+
+    mock_callback_query.answer.assert_called_once_with()
+    mock_message.reply.assert_called_once_with("Error handled")
+
+
+@pytest.mark.asyncio
+async def test_bot_on_error_notifies_when_callback_query_was_already_answered(mock_callback_query: MagicMock, mock_message: MagicMock) -> None:
+    event = MagicMock(spec=ErrorEvent)
+    event.exception = RuntimeError("Handler panic")
+    update = MagicMock()
+    update.message = None
+    update.callback_query = mock_callback_query
+    event.update = update
+    mock_callback_query.answer.side_effect = Exception("query is too old")
+
+    with patch("src.client.telegram.handlers.errors.translate", return_value="Error handled"):
+        await error_handler(event)
+
+    mock_message.reply.assert_called_once_with("Error handled")
+
+
+@pytest.mark.asyncio
+async def test_bot_on_error_answers_callback_query_on_inaccessible_message(mock_callback_query: MagicMock) -> None:
+    event = MagicMock(spec=ErrorEvent)
+    event.exception = RuntimeError("Handler panic")
+    update = MagicMock()
+    update.message = None
+    update.callback_query = mock_callback_query
+    mock_callback_query.message = None
+    event.update = update
+
+    await error_handler(event)
+
+    mock_callback_query.answer.assert_called_once_with()
@@ -0,0 +1,90 @@
+from unittest.mock import AsyncMock, MagicMock
+
+import pytest
+from aiogram.types import Message, MessageEntity, User
+from src.client.telegram.handlers.group_mention import AddressedToBotFilter
+from src.config import Settings
+
+BOT_ID = 42
+URL = "https://youtu.be/dQw4w9WgXcQ"
+
+
+def build_settings(group_mention_only: bool = True) -> Settings:
+    settings = MagicMock(spec=Settings)
+    settings.telegram_group_mention_only = group_mention_only
+    return settings
+
+
+def build_bot() -> AsyncMock:
+    me = MagicMock(spec=User)
+    me.id = BOT_ID
+    me.username = "BrieflyBot"
+    bot = AsyncMock()
+    bot.me.return_value = me
+    return bot
+
+
+def build_group_message(text: str, entities: list[MessageEntity] | None = None, reply_to_user_id: int | None = None) -> MagicMock:
+    message = MagicMock(spec=Message)
+    message.chat = MagicMock(type="supergroup")
+    message.text = text
+    message.entities = entities
+    message.caption = None
+    message.caption_entities = None
+    message.reply_to_message = None
+    if reply_to_user_id is not None:
+        message.reply_to_message = MagicMock(spec=Message)
+        message.reply_to_message.from_user = MagicMock(spec=User, id=reply_to_user_id)
+    return message
+
+
+def mention(text: str, username: str) -> MessageEntity:
+    return MessageEntity(type="mention", offset=text.index(username), length=len(username))
+
+
+@pytest.mark.asyncio
+async def test_group_message_mentioning_bot_passes() -> None:
+    text = f"@brieflybot {URL}"
+    message = build_group_message(text, [mention(text, "@brieflybot")])
+
+    assert await AddressedToBotFilter()(message, build_bot(), build_settings()) is True
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(
+    ("text", "username"),
+    [(URL, None), (f"@other_bot {URL}", "@other_bot")],
+)
+async def test_group_message_not_mentioning_bot_is_filtered(text: str, username: str | None) -> None:
+    message = build_group_message(text, [mention(text, username)] if username else None)
+
+    assert await AddressedToBotFilter()(message, build_bot(), build_settings()) is False
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(("reply_to_user_id", "expected_passes"), [(BOT_ID, True), (7, False)])
+async def test_group_reply_passes_only_if_replying_to_bot(reply_to_user_id: int, expected_passes: bool) -> None:
+    message = build_group_message(URL, reply_to_user_id=reply_to_user_id)
+
+    assert await AddressedToBotFilter()(message, build_bot(), build_settings()) is expected_passes
+
+
+@pytest.mark.asyncio
+async def test_group_caption_with_text_mention_passes() -> None:
+    message = build_group_message("")
+    message.text = None
+    message.caption = f"Bot {URL}"
+    message.caption_entities = [MessageEntity(type="text_mention", offset=0, length=3, user=User(id=BOT_ID, is_bot=True, first_name="Briefly"))]
+
+    assert await AddressedToBotFilter()(message, build_bot(), build_settings()) is True
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(("chat_type", "group_mention_only"), [("private", True), ("group", False)])
+async def test_message_passes_without_mention_in_private_chat_or_when_disabled(chat_type: str, group_mention_only: bool) -> None:
+    message = build_group_message(URL)
+    message.chat.type = chat_type
+    bot = build_bot()
+
+    assert await AddressedToBotFilter()(message, bot, build_settings(group_mention_only)) is True
+    bot.me.assert_not_called()
@@ -1,74 +1,98 @@
-from typing import Any
 from unittest.mock import AsyncMock, MagicMock, patch
 
 import pytest
-from aiogram.types import ErrorEvent, Message, User
-from src.client.telegram.handlers.commands import start_command
-from src.client.telegram.handlers.errors import error_handler
-from src.client.telegram.handlers.messages import handle_message
-from src.config import Settings
+from src.client.telegram.handlers.messages import handle_message, handle_unsupported_message
+from src.config import FeatureFlags
+from src.load.errors import NoSubtitlesError, VideoPrivateError, VideoUnavailableError
 from src.load.video_loader import VideoTranscript
+from src.transform.summarization import SummarizationTimeoutError, SummaryRefusedError
 
 
-@pytest.fixture
-def mock_settings() -> Settings:
-    settings = MagicMock(spec=Settings)
-    settings.rate_limit_window_seconds = 10
-    settings.telegram_bot_token = "123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11"
-    settings.openai_base_url = "https://api.openai.com/v1/"
-    settings.openai_api_key = "test_key"
-    settings.openai_model = "gpt-3.5-turbo"
-    settings.valkey_url = None
-    settings.cache_compression_method = "gzip"
-    settings.yt_dlp_additional_options = ()
-    settings.max_telegram_message_length = 4000
-    return settings
+@pytest.mark.asyncio
+async def test_bot_handle_message_no_text(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    mock_message.text = None
+    with (
+        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
+        patch("src.client.telegram.handlers.messages.translate", return_value="No URL"),
+    ):
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+    assert mock_message.reply.call_count == 0
 
 
-@pytest.fixture
-def mock_deps(mock_settings: Settings) -> Any:
-    class Deps:
-        loader = AsyncMock()
-        summarizer = AsyncMock()
-        rate_limiter = AsyncMock()
-        settings = mock_settings
+@pytest.mark.asyncio
+async def test_bot_handle_message_uses_caption(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    transcript = VideoTranscript(id="123", language="en", uploader="test", title="Test Video", thumbnail="", transcript="Test transcript")
+    mock_message.text = None
+    mock_message.caption = "Worth watching: https://youtu.be/dQw4w9WgXcQ"
+    mock_message.reply.return_value = AsyncMock()
 
-    return Deps()
+    with (
+        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
+        patch.object(mock_deps.loader, "load", return_value=transcript) as mock_load,
+        patch.object(mock_deps.summarizer, "summarize", return_value="Test summary"),
+        patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.progress.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.summary_reply.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.style_keyboard.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
 
+    mock_load.assert_called_once_with("https://www.youtube.com/watch?v=dQw4w9WgXcQ", refresh=False)
 
-@pytest.fixture
-def mock_message() -> MagicMock:
-    user = MagicMock(spec=User)
-    user.id = 123
-    user.username = "testuser"
-    user.language_code = "en"
-    user.is_bot = False
 
-    message = AsyncMock(spec=Message)
-    message.message_id = 1
-    message.from_user = user
-    message.text = "https://youtube.com/watch?v=123"
-    message.reply = AsyncMock()
-    return message
+@pytest.mark.asyncio
+async def test_bot_handle_message_links_to_the_same_video_are_one_url(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    transcript = VideoTranscript(id="123", language="en", uploader="test", title="Test Video", thumbnail="", transcript="Test transcript")
+    mock_message.text = "https://youtu.be/dQw4w9WgXcQ, full link: https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42"
+    mock_message.reply.return_value = AsyncMock()
 
+    with (
+        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
+        patch.object(mock_deps.loader, "load", return_value=transcript) as mock_load,
+        patch.object(mock_deps.summarizer, "summarize", return_value="Test summary"),
+        patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.progress.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.summary_reply.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.style_keyboard.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
 
-@pytest.mark.asyncio
-async def test_bot_start(mock_message: MagicMock) -> None:
-    # Need to mock translate since we aren't loading translations in unit tests by default
-    with patch("src.client.telegram.handlers.commands.translate", return_value="Welcome"):
-        await start_command(mock_message)
-    mock_message.reply.assert_called_once_with("Welcome")
+    mock_load.assert_called_once_with("https://www.youtube.com/watch?v=dQw4w9WgXcQ", refresh=False)
 
 
 @pytest.mark.asyncio
-async def test_bot_handle_message_no_text(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+async def test_bot_handle_unsupported_message(mock_deps: MagicMock, mock_message: MagicMock) -> None:
     mock_message.text = None
-    with (
-        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
-        patch("src.client.telegram.handlers.messages.translate", return_value="No URL"),
-    ):
-        await handle_message(mock_message, mock_deps.loader, mock_deps.summarizer, mock_deps.rate_limiter, mock_deps.settings)
-    assert mock_message.reply.call_count == 0
+    mock_message.content_type = "voice"
+
+    with patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key):
+        await handle_unsupported_message(mock_message, mock_deps.language_preferences)
+
+    mock_message.reply.assert_called_once_with("telegram.error.unsupported_message")
 
 
 @pytest.mark.asyncio
@@ -77,22 +101,46 @@ async def test_bot_handle_message_rate_limited(mock_deps: MagicMock, mock_messag
         patch.object(mock_deps.rate_limiter, "is_limited", return_value=True),
         patch("src.client.telegram.handlers.messages.translate", return_value="Rate Limited"),
     ):
-        await handle_message(mock_message, mock_deps.loader, mock_deps.summarizer, mock_deps.rate_limiter, mock_deps.settings)
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
     mock_message.reply.assert_called_once_with("Rate Limited")
 
 
 @pytest.mark.asyncio
 async def test_bot_handle_message_multiple_urls(mock_deps: MagicMock, mock_message: MagicMock) -> None:
-    mock_message.text = "url1.com url2.com"
+    mock_message.text = "https://youtu.be/dQw4w9WgXcQ https://vkvideo.ru/video-123_456"
     with (
         patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
-        patch("src.client.telegram.handlers.messages.extract_urls", return_value=["url1", "url2"]),
         patch("src.client.telegram.handlers.messages.translate", return_value="Error"),
     ):
         processing_msg_mock = AsyncMock()
         mock_message.reply.return_value = processing_msg_mock
-        await handle_message(mock_message, mock_deps.loader, mock_deps.summarizer, mock_deps.rate_limiter, mock_deps.settings)
-        processing_msg_mock.edit_text.assert_called_once_with("Error")
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+        processing_msg_mock.edit_text.assert_called_once()
+
+    assert processing_msg_mock.edit_text.call_args.args == ("Error",)
+    buttons = [button for row in processing_msg_mock.edit_text.call_args.kwargs["reply_markup"].inline_keyboard for button in row]
+    assert [button.text for button in buttons] == ["1. YouTube: dQw4w9WgXcQ", "2. VK Video: video-123_456"]
+    assert [call.args[0] for call in mock_deps.source_store.remember.call_args_list] == [
+        "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+        "https://vkvideo.ru/video-123_456",
+    ]
+    mock_deps.loader.load.assert_not_called()
 
 
 @pytest.mark.asyncio
@@ -104,15 +152,26 @@ async def test_bot_handle_message_success(mock_deps: MagicMock, mock_message: Ma
 
     with (
         patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
-        patch("src.client.telegram.handlers.messages.extract_urls", return_value=["https://youtube.com/watch?v=123"]),
+        patch("src.client.telegram.handlers.messages.extract_canonical_urls", return_value=["https://youtube.com/watch?v=123"]),
         patch.object(mock_deps.loader, "load", return_value=transcript) as mock_load,
         patch.object(mock_deps.summarizer, "summarize", return_value="Test summary") as mock_summarize,
         patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.progress.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.summary_reply.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.style_keyboard.translate", side_effect=lambda key, **kw: key),
     ):
-        await handle_message(mock_message, mock_deps.loader, mock_deps.summarizer, mock_deps.rate_limiter, mock_deps.settings)
-
-        mock_load.assert_called_once_with("https://youtube.com/watch?v=123")
-        mock_summarize.assert_called_once_with("Test transcript", "en")
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+        mock_load.assert_called_once_with("https://youtube.com/watch?v=123", refresh=False)
+        mock_summarize.assert_called_once_with("Test transcript", "en", None, refresh=False)
 
         # Original message reply for processing, and second reply for final result
         expected_calls = 2
@@ -120,6 +179,100 @@ async def test_bot_handle_message_success(mock_deps: MagicMock, mock_message: Ma
         processing_msg_mock.delete.assert_called_once()
 
 
+@pytest.mark.asyncio
+@pytest.mark.parametrize(
+    ("error", "expected_reply"),
+    [
+        (VideoPrivateError("Failed to load video info: Private video"), "telegram.error.video_private"),
+        (VideoUnavailableError("Failed to load video info: Video unavailable"), "telegram.error.video_unavailable"),
+        (NoSubtitlesError("no subtitles found"), "telegram.error.no_subtitles"),
+    ],
+)
+async def test_bot_handle_message_explains_load_failure(
+    mock_deps: MagicMock, mock_message: MagicMock, error: Exception, expected_reply: str
+) -> None:
+    mock_message.text = "https://youtu.be/dQw4w9WgXcQ"
+    processing_msg_mock = AsyncMock()
+    mock_message.reply.return_value = processing_msg_mock
+    mock_deps.rate_limiter.is_limited.return_value = False
+    mock_deps.loader.load.side_effect = error
+
+    with (
+        patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.progress.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    processing_msg_mock.edit_text.assert_called_with(expected_reply)
+    mock_deps.summarizer.summarize.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_bot_handle_message_skips_short_transcript(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    transcript = VideoTranscript(id="123", language="en", uploader="test", title="Test Video", thumbnail="", transcript="Too short")
+    mock_message.text = "https://youtu.be/dQw4w9WgXcQ"
+    processing_msg_mock = AsyncMock()
+    mock_message.reply.return_value = processing_msg_mock
+    mock_deps.rate_limiter.is_limited.return_value = False
+    mock_deps.loader.load.return_value = transcript
+    mock_deps.settings.min_transcript_chars = 100
+
+    with (
+        patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.progress.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.short_transcript.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    processing_msg_mock.edit_text.assert_called_with("telegram.error.transcript_too_short")
+    mock_deps.summarizer.summarize.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_bot_handle_message_summarizes_transcript_reaching_minimum(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    transcript = VideoTranscript(id="123", language="en", uploader="test", title="Test Video", thumbnail="", transcript="Test transcript")
+    mock_message.text = "https://youtu.be/dQw4w9WgXcQ"
+    mock_message.reply.return_value = AsyncMock()
+    mock_deps.rate_limiter.is_limited.return_value = False
+    mock_deps.loader.load.return_value = transcript
+    mock_deps.summarizer.summarize.return_value = "Test summary"
+    mock_deps.settings.min_transcript_chars = len("Test transcript")
+
+    with (
+        patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.progress.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.summary_reply.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.style_keyboard.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    mock_deps.summarizer.summarize.assert_called_once()
+
+
 @pytest.mark.asyncio
 async def test_bot_handle_message_loader_fails(mock_deps: MagicMock, mock_message: MagicMock) -> None:
     processing_msg_mock = AsyncMock()
@@ -127,11 +280,20 @@ async def test_bot_handle_message_loader_fails(mock_deps: MagicMock, mock_messag
 
     with (
         patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
-        patch("src.client.telegram.handlers.messages.extract_urls", return_value=["https://youtube.com/watch?v=123"]),
+        patch("src.client.telegram.handlers.messages.extract_canonical_urls", return_value=["https://youtube.com/watch?v=123"]),
         patch.object(mock_deps.loader, "load", side_effect=Exception("Load error")),
         patch("src.client.telegram.handlers.messages.translate", return_value="Fail"),
+        patch("src.client.telegram.handlers.progress.translate", return_value="Fail"),
     ):
-        await handle_message(mock_message, mock_deps.loader, mock_deps.summarizer, mock_deps.rate_limiter, mock_deps.settings)
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
         processing_msg_mock.edit_text.assert_called_with("Fail")
 
 
@@ -143,23 +305,133 @@ async def test_bot_handle_message_summarizer_fails(mock_deps: MagicMock, mock_me
 
     with (
         patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
-        patch("src.client.telegram.handlers.messages.extract_urls", return_value=["https://youtube.com/watch?v=123"]),
+        patch("src.client.telegram.handlers.messages.extract_canonical_urls", return_value=["https://youtube.com/watch?v=123"]),
         patch.object(mock_deps.loader, "load", return_value=transcript),
         patch.object(mock_deps.summarizer, "summarize", side_effect=Exception("Summarize error")),
         patch("src.client.telegram.handlers.messages.translate", return_value="Fail"),
+        patch("src.client.telegram.handlers.progress.translate", return_value="Fail"),
     ):
-        await handle_message(mock_message, mock_deps.loader, mock_deps.summarizer, mock_deps.rate_limiter, mock_deps.settings)
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
         processing_msg_mock.edit_text.assert_called_with("Fail")
 
 
 @pytest.mark.asyncio
-async def test_bot_on_error(mock_message: MagicMock) -> None:
-    event = MagicMock(spec=ErrorEvent)
-    event.exception = Exception("General error")
-    update = MagicMock()
-    update.message = mock_message
-    event.update = update
+async def test_bot_handle_message_uses_preferred_language(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    transcript = VideoTranscript(id="123", language="en", uploader="test", title="Test Video", thumbnail="", transcript="Test transcript")
+    mock_message.text = "https://youtube.com/watch?v=123"
+    mock_message.reply.return_value = AsyncMock()
+    mock_deps.language_preferences.get.return_value = "de"
+
+    with (
+        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
+        patch("src.client.telegram.handlers.messages.extract_canonical_urls", return_value=["https://youtube.com/watch?v=123"]),
+        patch.object(mock_deps.loader, "load", return_value=transcript),
+        patch.object(mock_deps.summarizer, "summarize", return_value="Zusammenfassung") as mock_summarize,
+        patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.progress.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.summary_reply.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.style_keyboard.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    mock_deps.language_preferences.get.assert_called_once_with(123)
+    mock_summarize.assert_called_once_with("Test transcript", "de", None, refresh=False)
+
+
+@pytest.mark.asyncio
+async def test_bot_handle_message_summary_timeout(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    transcript = VideoTranscript(id="123", language="en", uploader="test", title="Test Video", thumbnail="", transcript="Test transcript")
+    processing_msg_mock = AsyncMock()
+    mock_message.reply.return_value = processing_msg_mock
+
+    with (
+        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
+        patch("src.client.telegram.handlers.messages.extract_canonical_urls", return_value=["https://youtube.com/watch?v=123"]),
+        patch.object(mock_deps.loader, "load", return_value=transcript),
+        patch.object(mock_deps.summarizer, "summarize", side_effect=SummarizationTimeoutError("timed out")),
+        patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.progress.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    processing_msg_mock.edit_text.assert_called_with("telegram.error.summary_timeout")
+
+
+@pytest.mark.asyncio
+async def test_bot_handle_message_summary_refused(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    transcript = VideoTranscript(id="123", language="en", uploader="test", title="Test Video", thumbnail="", transcript="Test transcript")
+    processing_msg_mock = AsyncMock()
+    mock_message.reply.return_value = processing_msg_mock
+
+    with (
+        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
+        patch("src.client.telegram.handlers.messages.extract_canonical_urls", return_value=["https://youtube.com/watch?v=123"]),
+        patch.object(mock_deps.loader, "load", return_value=transcript),
+        patch.object(mock_deps.summarizer, "summarize", side_effect=SummaryRefusedError("model refused to summarize")),
+        patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.progress.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    processing_msg_mock.edit_text.assert_called_with("telegram.error.summary_unavailable")
+
+
+@pytest.mark.asyncio
+async def test_bot_handle_message_without_style_keyboard(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    transcript = VideoTranscript(id="123", language="en", uploader="test", title="Test Video", thumbnail="", transcript="Test transcript")
+    mock_deps.settings.feature_flags = FeatureFlags(style_keyboard=False)
+    mock_message.reply.return_value = AsyncMock()
 
-    with patch("src.client.telegram.handlers.errors.translate", return_value="Error handled"):
-        await error_handler(event)
-    mock_message.reply.assert_called_once_with("Error handled")
+    with (
+        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
+        patch("src.client.telegram.handlers.messages.extract_canonical_urls", return_value=["https://youtube.com/watch?v=123"]),
+        patch.object(mock_deps.loader, "load", return_value=transcript),
+        patch.object(mock_deps.summarizer, "summarize", return_value="Test summary"),
+        patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.progress.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.summary_reply.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    mock_deps.source_store.remember.assert_not_called()
+    assert mock_message.reply.call_args.kwargs["reply_markup"] is None
@@ -0,0 +1,44 @@
+from unittest.mock import MagicMock
+
+import pytest
+from aiogram.types import User
+from src.cache import InMemoryCacheProvider
+from src.client.telegram.handlers.language_resolution import get_preferred_language
+from src.language_preferences import UserLanguagePreferences
+
+
+def build_user(language_code: str = "en") -> MagicMock:
+    user = MagicMock(spec=User)
+    user.id = 123
+    user.language_code = language_code
+    return user
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(
+    ("chat_type", "chat_language", "user_language", "expected_language"),
+    [
+        ("supergroup", "ja", "de", "ja"),
+        ("supergroup", None, "de", "de"),
+        ("supergroup", None, None, "ru"),
+        ("private", "ja", None, "ru"),
+    ],
+)
+async def test_chat_language_takes_precedence_in_groups(
+    chat_type: str, chat_language: str | None, user_language: str | None, expected_language: str
+) -> None:
+    preferences = UserLanguagePreferences(InMemoryCacheProvider())
+    chat = MagicMock(id=-100123 if chat_type != "private" else 123, type=chat_type)
+    await preferences.set_chat(chat.id, chat_language)
+    if user_language:
+        await preferences.set(123, user_language)
+
+    assert await get_preferred_language(build_user("ru"), preferences, chat) == expected_language
+
+
+@pytest.mark.asyncio
+async def test_preferred_language_without_chat_uses_user_language() -> None:
+    preferences = UserLanguagePreferences(InMemoryCacheProvider())
+    await preferences.set(123, "de")
+
+    assert await get_preferred_language(build_user(), preferences) == "de"
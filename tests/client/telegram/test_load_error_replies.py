@@ -0,0 +1,17 @@
+import pytest
+from src.client.telegram.handlers.load_error_replies import load_error_key
+from src.load.errors import InvalidVideoUrlError, NoSubtitlesError, VideoPrivateError, VideoUnavailableError
+
+
+@pytest.mark.parametrize(
+    ("error", "expected_key"),
+    [
+        (InvalidVideoUrlError("no valid URL found"), "telegram.error.no_url_found"),
+        (VideoPrivateError("Failed to load video info: Private video"), "telegram.error.video_private"),
+        (VideoUnavailableError("Failed to load video info: Video unavailable"), "telegram.error.video_unavailable"),
+        (NoSubtitlesError("no subtitles found"), "telegram.error.no_subtitles"),
+        (RuntimeError("Failed to load video info after 3 attempts: timed out"), "telegram.error.transcript_failed"),
+    ],
+)
+def test_load_error_key(error: Exception, expected_key: str) -> None:
+    assert load_error_key(error) == expected_key
@@ -198,8 +198,13 @@ async def test_main_function_initializes_and_runs() -> None:
         patch("src.client.telegram.main.Settings") as mock_settings,
         patch("src.client.telegram.main.get_cache_provider") as mock_get_cache_provider,
         patch("src.client.telegram.main.UserRateLimiter") as mock_rate_limiter,
-        patch("src.client.telegram.main.VideoDataLoader") as mock_loader,
+        patch("src.client.telegram.main.ProviderVideoLoader") as mock_loader,
         patch("src.client.telegram.main.OpenAISummarizer") as mock_summarizer,
+        patch("src.client.telegram.main.SummarySourceStore") as mock_source_store,
+        patch("src.client.telegram.main.UserLanguagePreferences") as mock_language_preferences,
+        patch("src.client.telegram.main.ModelSelection") as mock_model_selection,
+        patch("src.client.telegram.main.ConcurrencyLimitMiddleware") as mock_concurrency_middleware,
+        patch("src.client.telegram.main.DeduplicationMiddleware") as mock_deduplication_middleware,
         patch("src.client.telegram.main.Dispatcher") as mock_dispatcher_class,
         patch("src.client.telegram.main.Bot") as mock_bot_class,
     ):
@@ -211,6 +216,7 @@ async def test_main_function_initializes_and_runs() -> None:
         mock_dispatcher_class.return_value = mock_dp_obj
 
         mock_bot_obj = MagicMock()
+        mock_bot_obj.delete_webhook = AsyncMock()
         mock_bot_class.return_value = mock_bot_obj
 
         # Call main function
@@ -219,18 +225,58 @@ async def test_main_function_initializes_and_runs() -> None:
         # Verify all steps were called in sequence
         mock_settings.from_env.assert_called_once()
         mock_get_cache_provider.assert_called_once_with(mock_settings_obj)
+        mock_model_selection.assert_called_once_with(mock_get_cache_provider.return_value, mock_settings_obj)
+        mock_summarizer.assert_called_once_with(mock_settings_obj, mock_model_selection.return_value)
         mock_dispatcher_class.assert_called_once()
         mock_dp_obj.include_routers.assert_called_once()
+        mock_concurrency_middleware.assert_called_once_with(mock_settings_obj.bot_max_concurrency)
+        mock_dp_obj.message.middleware.assert_called_once_with(mock_concurrency_middleware.return_value)
+        mock_dp_obj.callback_query.middleware.assert_called_once_with(mock_concurrency_middleware.return_value)
+        mock_deduplication_middleware.assert_called_once_with(mock_get_cache_provider.return_value)
+        mock_dp_obj.message.outer_middleware.assert_any_call(mock_deduplication_middleware.return_value)
+        mock_dp_obj.update.outer_middleware.assert_called_once()
         mock_bot_class.assert_called_once()
+        mock_bot_obj.delete_webhook.assert_awaited_once()
         mock_dp_obj.start_polling.assert_called_once_with(
             mock_bot_obj,
             settings=mock_settings_obj,
             rate_limiter=mock_rate_limiter.return_value,
             loader=mock_loader.return_value,
             summarizer=mock_summarizer.return_value,
+            source_store=mock_source_store.return_value,
+            language_preferences=mock_language_preferences.return_value,
+            model_selection=mock_model_selection.return_value,
         )
 
 
+@pytest.mark.asyncio
+async def test_main_serves_webhook_in_webhook_mode() -> None:
+    with (
+        patch("src.client.telegram.main.Settings") as mock_settings,
+        patch("src.client.telegram.main.get_cache_provider"),
+        patch("src.client.telegram.main.UserRateLimiter"),
+        patch("src.client.telegram.main.ProviderVideoLoader"),
+        patch("src.client.telegram.main.OpenAISummarizer"),
+        patch("src.client.telegram.main.SummarySourceStore"),
+        patch("src.client.telegram.main.ConcurrencyLimitMiddleware"),
+        patch("src.client.telegram.main.Dispatcher") as mock_dispatcher_class,
+        patch("src.client.telegram.main.Bot") as mock_bot_class,
+        patch("src.client.telegram.main.run_webhook", new_callable=AsyncMock) as mock_run_webhook,
+    ):
+        mock_settings_obj = MagicMock()
+        mock_settings_obj.telegram_mode = "webhook"
+        mock_settings.from_env.return_value = mock_settings_obj
+        mock_dp_obj = MagicMock()
+        mock_dp_obj.start_polling = AsyncMock()
+        mock_dispatcher_class.return_value = mock_dp_obj
+
+        await main()
+
+        mock_run_webhook.assert_awaited_once()
+        assert mock_run_webhook.await_args.args == (mock_dp_obj, mock_bot_class.return_value, mock_settings_obj)
+        mock_dp_obj.start_polling.assert_not_called()
+
+
 def test_main_function_structure() -> None:
     # Just test that the main function exists and has the expected structure
     # by checking it has the expected attributes
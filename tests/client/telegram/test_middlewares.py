@@ -0,0 +1,200 @@
+import asyncio
+from typing import Any
+from unittest.mock import AsyncMock, MagicMock, patch
+
+import pytest
+from aiogram.types import CallbackQuery, Message, Update, User
+from src.cache import InMemoryCacheProvider
+from src.client.telegram.middlewares import (
+    ChatAccessMiddleware,
+    ConcurrencyLimitMiddleware,
+    DeduplicationMiddleware,
+    LogContextMiddleware,
+    UpdateDeduplicationMiddleware,
+)
+from src.log_context import _log_context
+
+
+def build_message() -> MagicMock:
+    user = MagicMock(spec=User)
+    user.id = 123
+    user.language_code = "en"
+
+    message = AsyncMock(spec=Message)
+    message.from_user = user
+    message.reply = AsyncMock()
+    message.chat = MagicMock(id=456)
+    message.message_id = 789
+    return message
+
+
+@pytest.mark.asyncio
+async def test_concurrency_limit_never_exceeds_cap() -> None:
+    max_concurrency = 2
+    middleware = ConcurrencyLimitMiddleware(max_concurrency)
+    release = asyncio.Event()
+    state = {"running": 0, "peak": 0}
+
+    async def handler(event: Any, data: dict[str, Any]) -> str:
+        state["running"] += 1
+        state["peak"] = max(state["peak"], state["running"])
+        await release.wait()
+        state["running"] -= 1
+        return "done"
+
+    messages = [build_message() for _ in range(5)]
+    with patch("src.client.telegram.middlewares.concurrency.translate", return_value="Busy"):
+        tasks = [asyncio.create_task(middleware(handler, message, {})) for message in messages]
+        await asyncio.sleep(0)
+        release.set()
+        results = await asyncio.gather(*tasks)
+
+    assert state["peak"] == max_concurrency
+    assert results.count("done") == max_concurrency
+    rejected = [message for message in messages if message.reply.call_count]
+    assert len(rejected) == len(messages) - max_concurrency
+    for message in rejected:
+        message.reply.assert_called_once_with("Busy")
+
+
+@pytest.mark.asyncio
+async def test_concurrency_limit_released_on_handler_error() -> None:
+    middleware = ConcurrencyLimitMiddleware(1)
+
+    async def failing_handler(event: Any, data: dict[str, Any]) -> None:
+        raise RuntimeError("boom")
+
+    with pytest.raises(RuntimeError, match="boom"):
+        await middleware(failing_handler, build_message(), {})
+
+    handler = AsyncMock(return_value="done")
+    assert await middleware(handler, build_message(), {}) == "done"
+
+
+@pytest.mark.asyncio
+async def test_log_context_binds_user_id() -> None:
+    message = build_message()
+
+    async def handler(event: Any, data: dict[str, Any]) -> dict[str, object] | None:
+        return _log_context.get()
+
+    context = await LogContextMiddleware()(handler, message, {"event_from_user": message.from_user})
+
+    assert context == {"userID": 123, "correlation_id": "123"}
+    assert _log_context.get() is None
+
+
+@pytest.mark.asyncio
+async def test_deduplication_handles_redelivered_message_once() -> None:
+    middleware = DeduplicationMiddleware(InMemoryCacheProvider())
+    handler = AsyncMock(return_value="done")
+
+    first = await middleware(handler, build_message(), {})
+    duplicate = await middleware(handler, build_message(), {})
+
+    assert first == "done"
+    assert duplicate is None
+    handler.assert_called_once()
+
+
+@pytest.mark.asyncio
+async def test_deduplication_handles_distinct_messages() -> None:
+    middleware = DeduplicationMiddleware(InMemoryCacheProvider())
+    handler = AsyncMock(return_value="done")
+    other_message = build_message()
+    other_message.message_id = 790
+
+    await middleware(handler, build_message(), {})
+    await middleware(handler, other_message, {})
+
+    assert handler.call_count == 2
+
+
+def build_update(update_id: int) -> MagicMock:
+    update = MagicMock(spec=Update)
+    update.update_id = update_id
+    return update
+
+
+@pytest.mark.asyncio
+async def test_update_deduplication_processes_each_update_id_once() -> None:
+    middleware = UpdateDeduplicationMiddleware(InMemoryCacheProvider())
+    handler = AsyncMock(return_value="done")
+
+    # Webhook updates may arrive out of order; only repeated IDs are skipped.
+    results = [await middleware(handler, build_update(update_id), {}) for update_id in (101, 100, 101, 102, 100)]
+
+    assert results == ["done", "done", None, "done", None]
+    assert [call.args[0].update_id for call in handler.call_args_list] == [101, 100, 102]
+
+
+@pytest.mark.asyncio
+async def test_update_deduplication_survives_restart_with_shared_cache() -> None:
+    provider = InMemoryCacheProvider()
+    handler = AsyncMock(return_value="done")
+
+    await UpdateDeduplicationMiddleware(provider)(handler, build_update(100), {})
+    # E.g. the bot restarted in webhook mode and Telegram redelivered the update.
+    duplicate = await UpdateDeduplicationMiddleware(provider)(handler, build_update(100), {})
+
+    assert duplicate is None
+    handler.assert_called_once()
+
+
+def build_chat_message(chat_id: int, chat_type: str) -> MagicMock:
+    message = build_message()
+    message.chat = MagicMock(id=chat_id, type=chat_type)
+    return message
+
+
+@pytest.mark.asyncio
+async def test_chat_access_allows_private_chat() -> None:
+    middleware = ChatAccessMiddleware((), allow_groups=False, reply_when_denied=True)
+    handler = AsyncMock(return_value="done")
+
+    assert await middleware(handler, build_chat_message(123, "private"), {}) == "done"
+    handler.assert_called_once()
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(("reply_when_denied", "expected_replies"), [(True, 1), (False, 0)])
+async def test_chat_access_denies_group_when_groups_disallowed(reply_when_denied: bool, expected_replies: int) -> None:
+    middleware = ChatAccessMiddleware((), allow_groups=False, reply_when_denied=reply_when_denied)
+    handler = AsyncMock()
+    message = build_chat_message(-100123, "supergroup")
+
+    with patch("src.client.telegram.middlewares.chat_access.translate", return_value="not allowed"):
+        assert await middleware(handler, message, {}) is None
+
+    handler.assert_not_called()
+    assert message.reply.call_count == expected_replies
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(
+    ("chat_id", "chat_type", "expected_allowed"),
+    [(-100123, "supergroup", True), (-100999, "supergroup", False), (123, "private", True), (456, "private", False)],
+)
+async def test_chat_access_matches_allow_list(chat_id: int, chat_type: str, expected_allowed: bool) -> None:
+    middleware = ChatAccessMiddleware((-100123, 123), allow_groups=True, reply_when_denied=False)
+    handler = AsyncMock(return_value="done")
+
+    result = await middleware(handler, build_chat_message(chat_id, chat_type), {})
+
+    assert (result == "done") is expected_allowed
+    assert handler.called is expected_allowed
+
+
+@pytest.mark.asyncio
+async def test_chat_access_checks_chat_of_callback_message() -> None:
+    middleware = ChatAccessMiddleware((123,), allow_groups=True, reply_when_denied=True)
+    handler = AsyncMock()
+    query = AsyncMock(spec=CallbackQuery)
+    query.from_user = build_message().from_user
+    query.message = build_chat_message(-100999, "group")
+
+    with patch("src.client.telegram.middlewares.chat_access.translate", return_value="not allowed"):
+        await middleware(handler, query, {})
+
+    handler.assert_not_called()
+    query.answer.assert_called_once_with("not allowed", show_alert=True)
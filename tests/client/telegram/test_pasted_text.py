@@ -0,0 +1,74 @@
+from unittest.mock import AsyncMock, MagicMock, patch
+
+import pytest
+from src.client.telegram.handlers.messages import handle_message
+from src.client.telegram.handlers.pasted_text import is_pasted_transcript
+
+
+@pytest.mark.parametrize(
+    ("text", "min_length", "expected"),
+    [
+        ("x" * 1000, 1000, True),
+        ("x" * 999, 1000, False),
+        ("   " + "x" * 10 + "   ", 12, False),
+        ("x" * 5000, 0, False),
+    ],
+)
+def test_is_pasted_transcript(text: str, min_length: int, expected: bool) -> None:
+    assert is_pasted_transcript(text, min_length) is expected
+
+
+@pytest.mark.asyncio
+async def test_bot_handle_message_summarizes_long_pasted_text(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    pasted_text = "word " * 300
+    mock_message.text = pasted_text
+    processing_msg_mock = AsyncMock()
+    mock_message.reply.return_value = processing_msg_mock
+
+    with (
+        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
+        patch("src.client.telegram.handlers.messages.extract_canonical_urls", return_value=[]),
+        patch.object(mock_deps.summarizer, "summarize", return_value="Pasted summary") as mock_summarize,
+        patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.pasted_text.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    mock_deps.loader.load.assert_not_called()
+    mock_summarize.assert_called_once_with(pasted_text, "en")
+    # Progress message plus the summary
+    expected_calls = 2
+    assert mock_message.reply.call_count == expected_calls
+    assert mock_message.reply.call_args.kwargs["link_preview_options"].is_disabled is True
+    processing_msg_mock.delete.assert_called_once()
+
+
+@pytest.mark.asyncio
+async def test_bot_handle_message_short_text_without_url(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    mock_message.text = "hello there"
+
+    with (
+        patch.object(mock_deps.rate_limiter, "is_limited", return_value=False),
+        patch("src.client.telegram.handlers.messages.extract_canonical_urls", return_value=[]),
+        patch("src.client.telegram.handlers.messages.translate", side_effect=lambda key, **kw: key),
+    ):
+        await handle_message(
+            mock_message,
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+
+    mock_deps.summarizer.summarize.assert_not_called()
+    mock_message.reply.assert_called_once_with("telegram.error.no_url_found")
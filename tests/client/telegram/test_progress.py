@@ -0,0 +1,51 @@
+from unittest.mock import AsyncMock, MagicMock, patch
+
+import pytest
+from src.client.telegram.handlers.progress import ProgressTracker
+
+STEP_TEXTS = {
+    "telegram.progress.fetching_info": "🔍 Getting information about the video...",
+    "telegram.progress.fetching_transcript": "🎬 Getting a transcript...",
+    "telegram.progress.summarizing": "🤖 Summarizing...",
+}
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(
+    ("style", "expected_texts"),
+    [
+        (
+            "replace",
+            [
+                "🔍 Getting information about the video...",
+                "🎬 Getting a transcript...",
+                "🤖 Summarizing...",
+            ],
+        ),
+        (
+            "append",
+            [
+                "🔍 Getting information about the video...",
+                "✅ Getting information about the video\n🎬 Getting a transcript...",
+                "✅ Getting information about the video\n✅ Getting a transcript\n🤖 Summarizing...",
+            ],
+        ),
+    ],
+)
+async def test_progress_tracker_renders_each_step(style: str, expected_texts: list[str]) -> None:
+    message = AsyncMock()
+    tracker = ProgressTracker(message, "en", style)
+
+    with patch("src.client.telegram.handlers.progress.translate", side_effect=lambda key, **kw: STEP_TEXTS[key]):
+        for key in STEP_TEXTS:
+            await tracker.step(key)
+
+    assert [call.args[0] for call in message.edit_text.call_args_list] == expected_texts
+
+
+@pytest.mark.parametrize(("text", "expected_label"), [("Summarizing…", "Summarizing"), ("⏳ 要約中...", "要約中")])
+def test_progress_tracker_append_labels_without_icon(text: str, expected_label: str) -> None:
+    tracker = ProgressTracker(MagicMock(), "en", "append")
+    tracker.steps = [text, "🤖 Next"]
+
+    assert tracker.render() == f"✅ {expected_label}\n🤖 Next"
@@ -0,0 +1,119 @@
+from unittest.mock import AsyncMock, MagicMock, patch
+
+import pytest
+from aiogram.filters import CommandObject
+from aiogram.types import InlineKeyboardButton, InlineKeyboardMarkup, Message
+from src.client.telegram.handlers.refresh import refresh_command
+from src.client.telegram.handlers.style_keyboard import SummaryStyleCallback
+from src.transform.summary_style import SummaryStyle
+
+URL = "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+
+
+def build_reply(text: str | None = None, reply_markup: InlineKeyboardMarkup | None = None) -> MagicMock:
+    reply = MagicMock(spec=Message)
+    reply.text = text
+    reply.caption = None
+    reply.reply_markup = reply_markup
+    return reply
+
+
+def build_style_markup(token: str) -> InlineKeyboardMarkup:
+    button = InlineKeyboardButton(text="Short", callback_data=SummaryStyleCallback(style=SummaryStyle.SHORT, token=token).pack())
+    return InlineKeyboardMarkup(inline_keyboard=[[InlineKeyboardButton(text="Open", url=URL)], [button]])
+
+
+async def run_command(mock_deps: MagicMock, mock_message: MagicMock, args: str | None) -> AsyncMock:
+    with (
+        patch("src.client.telegram.handlers.refresh.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.refresh.summarize_video_url", new_callable=AsyncMock) as mock_summarize_video_url,
+    ):
+        await refresh_command(
+            mock_message,
+            CommandObject(command="refresh", args=args),
+            mock_deps.loader,
+            mock_deps.summarizer,
+            mock_deps.rate_limiter,
+            mock_deps.settings,
+            mock_deps.source_store,
+            mock_deps.language_preferences,
+        )
+    return mock_summarize_video_url
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(
+    ("args", "reply"),
+    [
+        ("https://youtu.be/dQw4w9WgXcQ", None),
+        (None, build_reply(text=f"Look at this: {URL}")),
+        (None, build_reply(reply_markup=build_style_markup("abc123"))),
+    ],
+)
+async def test_refresh_command_resummarizes_video_bypassing_cache(
+    mock_deps: MagicMock, mock_message: MagicMock, args: str | None, reply: MagicMock | None
+) -> None:
+    mock_message.reply_to_message = reply
+    mock_deps.rate_limiter.is_limited.return_value = False
+    mock_deps.source_store.resolve.return_value = URL
+    processing_message = AsyncMock()
+    mock_message.reply.return_value = processing_message
+
+    mock_summarize_video_url = await run_command(mock_deps, mock_message, args)
+
+    mock_message.reply.assert_called_once_with("telegram.progress.processing")
+    mock_summarize_video_url.assert_called_once_with(
+        mock_message,
+        processing_message,
+        URL,
+        "en",
+        mock_deps.loader,
+        mock_deps.summarizer,
+        mock_deps.settings,
+        mock_deps.source_store,
+        refresh=True,
+    )
+
+
+@pytest.mark.asyncio
+async def test_refresh_command_resolves_token_of_replied_summary(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    mock_message.reply_to_message = build_reply(reply_markup=build_style_markup("abc123"))
+    mock_deps.rate_limiter.is_limited.return_value = False
+    mock_deps.source_store.resolve.return_value = URL
+
+    await run_command(mock_deps, mock_message, None)
+
+    mock_deps.source_store.resolve.assert_called_once_with("abc123")
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(
+    ("reply", "resolved_url"),
+    [
+        (None, None),
+        (build_reply(text="no link here"), None),
+        (build_reply(reply_markup=build_style_markup("expired")), None),
+    ],
+)
+async def test_refresh_command_without_video_shows_usage(
+    mock_deps: MagicMock, mock_message: MagicMock, reply: MagicMock | None, resolved_url: str | None
+) -> None:
+    mock_message.reply_to_message = reply
+    mock_deps.source_store.resolve.return_value = resolved_url
+
+    mock_summarize_video_url = await run_command(mock_deps, mock_message, None)
+
+    mock_message.reply.assert_called_once_with("telegram.refresh.usage")
+    mock_summarize_video_url.assert_not_called()
+    mock_deps.rate_limiter.is_limited.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_refresh_command_rate_limited(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    mock_message.reply_to_message = None
+    mock_deps.rate_limiter.is_limited.return_value = True
+
+    mock_summarize_video_url = await run_command(mock_deps, mock_message, "https://youtu.be/dQw4w9WgXcQ")
+
+    mock_message.reply.assert_called_once_with("telegram.error.rate_limited")
+    mock_summarize_video_url.assert_not_called()
@@ -0,0 +1,92 @@
+from unittest.mock import AsyncMock, MagicMock, patch
+
+import pytest
+from aiogram.exceptions import TelegramBadRequest, TelegramNetworkError, TelegramRetryAfter, TelegramServerError
+from src.client.telegram.retry import BASE_DELAY_SECONDS, MAX_ATTEMPTS, send_with_retry
+
+
+@pytest.mark.asyncio
+async def test_send_with_retry_returns_result() -> None:
+    send = AsyncMock(return_value="sent")
+
+    with patch("src.client.telegram.retry.asyncio.sleep") as mock_sleep:
+        assert await send_with_retry(send) == "sent"
+
+    send.assert_called_once()
+    mock_sleep.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_send_with_retry_stops_on_bad_request() -> None:
+    send = AsyncMock(side_effect=TelegramBadRequest(method=MagicMock(), message="Bad Request: message is too long"))
+
+    with patch("src.client.telegram.retry.asyncio.sleep") as mock_sleep, pytest.raises(TelegramBadRequest):
+        await send_with_retry(send)
+
+    send.assert_called_once()
+    mock_sleep.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_send_with_retry_sends_plain_text_when_entities_cannot_be_parsed() -> None:
+    send = AsyncMock(side_effect=TelegramBadRequest(method=MagicMock(), message="Bad Request: can't parse entities: Unexpected end tag"))
+    plain_text_fallback = AsyncMock(return_value="sent")
+
+    assert await send_with_retry(send, plain_text_fallback=plain_text_fallback) == "sent"
+
+    send.assert_called_once()
+    plain_text_fallback.assert_called_once()
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(
+    ("message", "with_fallback"),
+    [("Bad Request: can't parse entities: Unexpected end tag", False), ("Bad Request: message is too long", True)],
+)
+async def test_send_with_retry_raises_bad_request_without_usable_fallback(message: str, with_fallback: bool) -> None:
+    send = AsyncMock(side_effect=TelegramBadRequest(method=MagicMock(), message=message))
+    plain_text_fallback = AsyncMock()
+
+    with pytest.raises(TelegramBadRequest):
+        await send_with_retry(send, plain_text_fallback=plain_text_fallback if with_fallback else None)
+
+    plain_text_fallback.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_send_with_retry_honors_retry_after() -> None:
+    retry_after = 7
+    send = AsyncMock(side_effect=[TelegramRetryAfter(method=MagicMock(), message="Too Many Requests", retry_after=retry_after), "sent"])
+
+    with patch("src.client.telegram.retry.asyncio.sleep") as mock_sleep:
+        assert await send_with_retry(send) == "sent"
+
+    mock_sleep.assert_called_once_with(retry_after)
+
+
+@pytest.mark.asyncio
+async def test_send_with_retry_backs_off_exponentially() -> None:
+    send = AsyncMock(
+        side_effect=[
+            TelegramNetworkError(method=MagicMock(), message="Connection reset"),
+            TelegramServerError(method=MagicMock(), message="Bad Gateway"),
+            "sent",
+        ]
+    )
+
+    with patch("src.client.telegram.retry.asyncio.sleep") as mock_sleep:
+        assert await send_with_retry(send, attempts=3, base_delay=0.5) == "sent"
+
+    assert [call.args[0] for call in mock_sleep.call_args_list] == [0.5, 1.0]
+
+
+@pytest.mark.asyncio
+async def test_send_with_retry_raises_last_error_when_exhausted() -> None:
+    send = AsyncMock(side_effect=TelegramNetworkError(method=MagicMock(), message="Connection reset"))
+
+    with patch("src.client.telegram.retry.asyncio.sleep") as mock_sleep, pytest.raises(TelegramNetworkError):
+        await send_with_retry(send)
+
+    assert send.call_count == MAX_ATTEMPTS
+    assert mock_sleep.call_count == MAX_ATTEMPTS - 1
+    assert mock_sleep.call_args_list[0].args[0] == BASE_DELAY_SECONDS
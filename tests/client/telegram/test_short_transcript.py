@@ -0,0 +1,57 @@
+from unittest.mock import AsyncMock, MagicMock, patch
+
+import pytest
+from src.client.telegram.handlers.short_transcript import reject_short_transcript
+from src.config import FeatureFlags
+from src.load.video_loader import VideoTranscript
+
+URL = "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+
+
+def build_transcript(text: str) -> VideoTranscript:
+    return VideoTranscript(id="123", language="en", uploader="test", title="Test Video", thumbnail="", transcript=text)
+
+
+async def run_guard(mock_settings: MagicMock, mock_message: MagicMock, transcript: VideoTranscript) -> tuple[bool, AsyncMock, AsyncMock]:
+    processing_message = AsyncMock()
+    with (
+        patch("src.client.telegram.handlers.short_transcript.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.short_transcript.reply_with_transcript", new_callable=AsyncMock) as mock_reply_with_transcript,
+    ):
+        rejected = await reject_short_transcript(mock_message, processing_message, URL, transcript, "en", mock_settings)
+    return rejected, processing_message, mock_reply_with_transcript
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(("text", "min_chars"), [("Long enough", 11), ("Anything", 0)])
+async def test_reject_short_transcript_passes_long_enough_transcript(
+    mock_settings: MagicMock, mock_message: MagicMock, text: str, min_chars: int
+) -> None:
+    mock_settings.min_transcript_chars = min_chars
+
+    rejected, processing_message, mock_reply_with_transcript = await run_guard(mock_settings, mock_message, build_transcript(text))
+
+    assert rejected is False
+    processing_message.edit_text.assert_not_called()
+    mock_reply_with_transcript.assert_not_called()
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(
+    ("text", "short_transcript_text", "expected_transcript_sent"),
+    [("Too short", False, False), ("Too short", True, True), ("   ", True, False)],
+)
+async def test_reject_short_transcript(
+    mock_settings: MagicMock, mock_message: MagicMock, text: str, short_transcript_text: bool, expected_transcript_sent: bool
+) -> None:
+    mock_settings.min_transcript_chars = 100
+    mock_settings.feature_flags = FeatureFlags(short_transcript_text=short_transcript_text)
+    transcript = build_transcript(text)
+
+    rejected, processing_message, mock_reply_with_transcript = await run_guard(mock_settings, mock_message, transcript)
+
+    assert rejected is True
+    processing_message.edit_text.assert_called_once_with("telegram.error.transcript_too_short")
+    assert mock_reply_with_transcript.called is expected_transcript_sent
+    if expected_transcript_sent:
+        mock_reply_with_transcript.assert_called_once_with(mock_message, URL, transcript, "en", mock_settings)
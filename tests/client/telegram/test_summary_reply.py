@@ -0,0 +1,198 @@
+from unittest.mock import AsyncMock, MagicMock, patch
+
+import pytest
+from aiogram.exceptions import TelegramBadRequest
+from src.client.telegram.handlers.summary_document import build_summary_document, build_summary_filename, should_send_as_file
+from src.client.telegram.handlers.summary_reply import (
+    build_summary_header,
+    reply_with_summary,
+    split_into_messages,
+    split_into_parts,
+)
+from src.config import FeatureFlags
+from src.load.video_loader import VideoTranscript
+
+
+@pytest.mark.parametrize(
+    ("summary", "threshold", "expected"),
+    [
+        ("x" * 100, 0, False),
+        ("x" * 100, 100, False),
+        ("x" * 101, 100, True),
+    ],
+)
+def test_should_send_as_file(summary: str, threshold: int, expected: bool) -> None:
+    assert should_send_as_file(summary, threshold) is expected
+
+
+def build_transcript(duration: int | None = None) -> VideoTranscript:
+    return VideoTranscript(
+        id="123", language="en", uploader="Test Channel", title="Test Video", thumbnail="", transcript="Test transcript", duration=duration
+    )
+
+
+def test_build_summary_header_passes_template_data(mock_settings: MagicMock) -> None:
+    mock_settings.summary_header_key = "telegram.response.title_detailed"
+
+    with patch("src.client.telegram.handlers.summary_reply.translate", return_value="Header") as mock_translate:
+        header = build_summary_header("https://youtube.com/watch?v=123", build_transcript(754), "de", mock_settings)
+
+    assert header == "Header"
+    mock_translate.assert_called_once_with(
+        "telegram.response.title_detailed",
+        locale="de",
+        title="Test Video",
+        url="https://youtube.com/watch?v=123",
+        uploader="Test Channel",
+        duration="12:34",
+    )
+
+
+@pytest.mark.asyncio
+async def test_reply_with_summary_without_header(mock_settings: MagicMock, mock_message: MagicMock) -> None:
+    mock_settings.feature_flags = FeatureFlags(summary_header=False)
+
+    with (
+        patch("src.client.telegram.handlers.summary_reply.translate") as mock_translate,
+        patch("src.client.telegram.handlers.summary_reply.markdown_to_telegram_html", side_effect=lambda text: text),
+    ):
+        await reply_with_summary(mock_message, "https://youtube.com/watch?v=123", build_transcript(), "The summary.", "en", mock_settings)
+
+    mock_translate.assert_not_called()
+    mock_message.reply.assert_called_once()
+    assert mock_message.reply.call_args.kwargs["text"] == "The summary."
+
+
+@pytest.mark.parametrize(("link_preview", "expected_first_disabled"), [(True, False), (False, True)])
+@pytest.mark.asyncio
+async def test_reply_with_summary_previews_only_first_chunk(
+    mock_settings: MagicMock, mock_message: MagicMock, link_preview: bool, expected_first_disabled: bool
+) -> None:
+    mock_settings.feature_flags = FeatureFlags(summary_header=False, link_preview=link_preview)
+    mock_settings.max_telegram_message_length = 200
+    summary = " ".join(f"Sentence number {index} of the summary." for index in range(40))
+
+    with (
+        patch("src.client.telegram.handlers.summary_reply.translate", side_effect=fake_part_label),
+        patch("src.client.telegram.handlers.summary_reply.markdown_to_telegram_html", side_effect=lambda text: text),
+    ):
+        await reply_with_summary(mock_message, "https://youtube.com/watch?v=123", build_transcript(), summary, "en", mock_settings)
+
+    previews = [call.kwargs["link_preview_options"] for call in mock_message.reply.call_args_list]
+    assert len(previews) > 1
+    assert previews[0].is_disabled is expected_first_disabled
+    assert previews[0].url == "https://youtube.com/watch?v=123"
+    assert all(preview.is_disabled for preview in previews[1:])
+
+
+@pytest.mark.asyncio
+async def test_reply_with_summary_sends_unparsable_chunk_as_plain_text(mock_settings: MagicMock, mock_message: MagicMock) -> None:
+    mock_settings.feature_flags = FeatureFlags(summary_header=False)
+    parse_error = TelegramBadRequest(method=MagicMock(), message="Bad Request: can't parse entities: Unsupported start tag")
+    mock_message.reply.side_effect = [parse_error, MagicMock()]
+
+    with (
+        patch("src.client.telegram.handlers.summary_reply.markdown_to_telegram_html", return_value="<b>The <i>summary.</b>"),
+        patch("src.client.telegram.handlers.summary_reply.telegram_html_to_text", return_value="The summary.") as mock_to_text,
+    ):
+        await reply_with_summary(mock_message, "https://youtube.com/watch?v=123", build_transcript(), "The summary.", "en", mock_settings)
+
+    mock_to_text.assert_called_once_with("<b>The <i>summary.</b>")
+    first_call, second_call = mock_message.reply.call_args_list
+    assert first_call.kwargs["text"] == "<b>The <i>summary.</b>"
+    assert "parse_mode" not in first_call.kwargs
+    assert second_call.kwargs["text"] == "The summary."
+    assert second_call.kwargs["parse_mode"] is None
+    assert second_call.kwargs["link_preview_options"] == first_call.kwargs["link_preview_options"]
+
+
+@pytest.mark.parametrize(
+    ("title", "expected"),
+    [
+        ("Never Gonna Give You Up", "Never_Gonna_Give_You_Up.md"),
+        ("What's new in Python 3.13?", "What_s_new_in_Python_3_13.md"),
+        ("../../etc/passwd", "etc_passwd.md"),
+        ("Привет, мир", "Привет_мир.md"),
+        ("???", "summary.md"),
+        ("a" * 100, "a" * 64 + ".md"),
+    ],
+)
+def test_build_summary_filename(title: str, expected: str) -> None:
+    assert build_summary_filename(title) == expected
+
+
+def test_build_summary_document_has_header() -> None:
+    document = build_summary_document("https://youtube.com/watch?v=123", build_transcript(), "Summary text")
+
+    assert document.filename == "Test_Video.md"
+    assert document.data.decode("utf-8") == "# [Test Video](https://youtube.com/watch?v=123)\n\n*Test Channel*\n\nSummary text\n"
+
+
+@pytest.mark.asyncio
+async def test_reply_with_summary_sends_document_above_threshold(mock_settings: MagicMock, mock_message: MagicMock) -> None:
+    mock_settings.send_as_file_threshold = 10
+    mock_message.reply_document = AsyncMock()
+
+    with patch("src.client.telegram.handlers.summary_reply.translate", side_effect=lambda key, **kw: key):
+        await reply_with_summary(
+            mock_message,
+            "https://youtube.com/watch?v=123",
+            build_transcript(),
+            "A summary longer than ten chars",
+            "en",
+            mock_settings,
+        )
+
+    mock_message.reply.assert_not_called()
+    mock_message.reply_document.assert_called_once()
+    document = mock_message.reply_document.call_args.args[0]
+    assert document.filename == "Test_Video.md"
+
+
+def fake_part_label(key: str, **kwargs: object) -> str:
+    return f"Part {kwargs['part']}/{kwargs['total']}"
+
+
+def test_split_into_parts_single_chunk_has_no_label() -> None:
+    with patch("src.client.telegram.handlers.summary_reply.translate", side_effect=fake_part_label) as mock_translate:
+        assert split_into_parts("Short summary.", "en", 100) == ["Short summary."]
+
+    mock_translate.assert_not_called()
+
+
+@pytest.mark.parametrize("max_length", [60, 100, 250])
+def test_split_into_parts_labels_chunks_within_limit(max_length: int) -> None:
+    response = " ".join(f"Sentence number {index} of the summary." for index in range(40))
+
+    with patch("src.client.telegram.handlers.summary_reply.translate", side_effect=fake_part_label):
+        parts = split_into_parts(response, "en", max_length)
+
+    total = len(parts)
+    assert total > 1
+    for index, part in enumerate(parts, start=1):
+        assert part.startswith(f"Part {index}/{total}\n")
+        assert len(part) <= max_length
+    assert " ".join(part.split("\n", 1)[1] for part in parts).split() == response.split()
+
+
+def expand_markdown(text: str) -> str:
+    # Stands in for Markdown to HTML conversion: every "**bold**" pair grows by 9 characters
+    return text.replace("**", "<b>", 1).replace("**", "</b>", 1).replace("&", "&amp;")
+
+
+@pytest.mark.parametrize("max_length", [200, 500, 4096])
+def test_split_into_messages_fits_rendered_html(max_length: int) -> None:
+    response = " ".join(f"**Point {index}** covers Tom & Jerry & friends." for index in range(300))
+
+    with patch("src.client.telegram.handlers.summary_reply.translate", side_effect=fake_part_label):
+        messages = split_into_messages(response, "en", max_length, render=expand_markdown)
+
+    assert len(messages) > 1
+    assert all(len(message) <= max_length for message in messages)
+    assert messages[-1].startswith(f"Part {len(messages)}/{len(messages)}")
+
+
+def test_split_into_messages_keeps_short_response_whole() -> None:
+    messages = split_into_messages("Tom & Jerry", "en", 4096, render=expand_markdown)
+
+    assert messages == ["Tom &amp; Jerry"]
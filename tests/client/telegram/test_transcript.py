@@ -0,0 +1,116 @@
+from unittest.mock import AsyncMock, MagicMock, patch
+
+import pytest
+from aiogram.filters import CommandObject
+from src.client.telegram.handlers.transcript import MAX_TRANSCRIPT_MESSAGES, transcript_command
+from src.load.errors import NoSubtitlesError
+from src.load.video_loader import VideoTranscript
+
+
+def build_transcript(text: str = "Never gonna give you up.") -> VideoTranscript:
+    return VideoTranscript(id="dQw4w9WgXcQ", language="en", uploader="test", title="Test Video", thumbnail="", transcript=text)
+
+
+async def run_command(mock_deps: MagicMock, mock_message: MagicMock, args: str | None) -> None:
+    await transcript_command(
+        mock_message,
+        CommandObject(command="transcript", args=args),
+        mock_deps.loader,
+        mock_deps.rate_limiter,
+        mock_deps.settings,
+        mock_deps.language_preferences,
+    )
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize("args", [None, "", "not a link"])
+async def test_transcript_command_without_url_shows_usage(mock_deps: MagicMock, mock_message: MagicMock, args: str | None) -> None:
+    with patch("src.client.telegram.handlers.transcript.translate", side_effect=lambda key, **kw: key):
+        await run_command(mock_deps, mock_message, args)
+
+    mock_message.reply.assert_called_once_with("telegram.transcript.usage")
+    mock_deps.loader.load.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_transcript_command_rate_limited(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    mock_deps.rate_limiter.is_limited.return_value = True
+
+    with patch("src.client.telegram.handlers.transcript.translate", side_effect=lambda key, **kw: key):
+        await run_command(mock_deps, mock_message, "https://youtu.be/dQw4w9WgXcQ")
+
+    mock_message.reply.assert_called_once_with("telegram.error.rate_limited")
+    mock_deps.loader.load.assert_not_called()
+
+
+@pytest.mark.asyncio
+async def test_transcript_command_sends_transcript_without_summarizing(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    mock_deps.rate_limiter.is_limited.return_value = False
+    mock_deps.loader.load.return_value = build_transcript("Tom & Jerry <3")
+    processing_msg_mock = AsyncMock()
+    mock_message.reply.return_value = processing_msg_mock
+
+    with (
+        patch("src.client.telegram.handlers.transcript.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.transcript.markdown_to_telegram_html", side_effect=lambda text: text),
+    ):
+        await run_command(mock_deps, mock_message, "https://youtu.be/dQw4w9WgXcQ")
+
+    mock_deps.loader.load.assert_called_once_with("https://www.youtube.com/watch?v=dQw4w9WgXcQ")
+    mock_deps.summarizer.summarize.assert_not_called()
+    assert mock_message.reply.call_args.kwargs["text"] == "telegram.response.title\nTom &amp; Jerry &lt;3"
+    processing_msg_mock.delete.assert_called_once()
+
+
+@pytest.mark.asyncio
+async def test_transcript_command_uses_first_link_of_any_provider(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    mock_deps.rate_limiter.is_limited.return_value = False
+    mock_deps.loader.load.return_value = build_transcript()
+    mock_message.reply.return_value = AsyncMock()
+
+    with (
+        patch("src.client.telegram.handlers.transcript.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.transcript.markdown_to_telegram_html", side_effect=lambda text: text),
+    ):
+        await run_command(mock_deps, mock_message, "https://vkvideo.ru/video-123_456 https://youtu.be/dQw4w9WgXcQ")
+
+    mock_deps.loader.load.assert_called_once_with("https://vkvideo.ru/video-123_456")
+
+
+@pytest.mark.asyncio
+async def test_transcript_command_sends_long_transcript_as_file(mock_deps: MagicMock, mock_message: MagicMock) -> None:
+    mock_deps.rate_limiter.is_limited.return_value = False
+    mock_deps.settings.max_telegram_message_length = 100
+    mock_deps.loader.load.return_value = build_transcript("Some words here. " * 10 * (MAX_TRANSCRIPT_MESSAGES + 1))
+    mock_message.reply_document = AsyncMock()
+
+    with (
+        patch("src.client.telegram.handlers.transcript.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.summary_reply.translate", side_effect=lambda key, **kw: key),
+        patch("src.client.telegram.handlers.transcript.markdown_to_telegram_html", side_effect=lambda text: text),
+    ):
+        await run_command(mock_deps, mock_message, "https://youtu.be/dQw4w9WgXcQ")
+
+    mock_message.reply_document.assert_called_once()
+    document = mock_message.reply_document.call_args.args[0]
+    assert document.filename == "Test_Video.txt"
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize(
+    ("error", "expected_reply"),
+    [
+        (RuntimeError("Failed to load video info after 3 attempts"), "telegram.error.transcript_failed"),
+        (NoSubtitlesError("no subtitles found"), "telegram.error.no_subtitles"),
+    ],
+)
+async def test_transcript_command_load_failure(mock_deps: MagicMock, mock_message: MagicMock, error: Exception, expected_reply: str) -> None:
+    mock_deps.rate_limiter.is_limited.return_value = False
+    mock_deps.loader.load.side_effect = error
+    processing_msg_mock = AsyncMock()
+    mock_message.reply.return_value = processing_msg_mock
+
+    with patch("src.client.telegram.handlers.transcript.translate", side_effect=lambda key, **kw: key):
+        await run_command(mock_deps, mock_message, "https://youtu.be/dQw4w9WgXcQ")
+
+    processing_msg_mock.edit_text.assert_called_once_with(expected_reply)
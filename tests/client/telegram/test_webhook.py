@@ -0,0 +1,110 @@
+import asyncio
+from unittest.mock import AsyncMock, MagicMock, patch
+
+import pytest
+from aiogram import Bot, Dispatcher
+from aiogram.types import Message
+# Aliased so pytest does not try to collect them as test classes.
+from aiohttp.test_utils import TestClient as HttpClient
+from aiohttp.test_utils import TestServer as HttpServer
+from src.client.telegram.webhook import build_webhook_app, run_webhook
+from src.config import Settings
+
+TOKEN = "123456:ABC-DEF1234ghIkl-zyx57W2v1u123ew11"
+SECRET_HEADER = "X-Telegram-Bot-Api-Secret-Token"
+UPDATE = {
+    "update_id": 1,
+    "message": {
+        "message_id": 1,
+        "date": 0,
+        "chat": {"id": 1, "type": "private"},
+        "from": {"id": 1, "is_bot": False, "first_name": "Test"},
+        "text": "https://youtu.be/dQw4w9WgXcQ",
+    },
+}
+
+
+def build_settings(**overrides: object) -> Settings:
+    settings = MagicMock(spec=Settings)
+    settings.telegram_webhook_url = "https://bot.example.com/telegram"
+    settings.telegram_webhook_token = "secret"
+    settings.telegram_webhook_host = "0.0.0.0"
+    settings.telegram_webhook_port = 8080
+    for key, value in overrides.items():
+        setattr(settings, key, value)
+    return settings
+
+
+def build_dispatcher(received: list[str], handled: asyncio.Event) -> Dispatcher:
+    dispatcher = Dispatcher()
+
+    async def handle_message(message: Message, loader: str) -> None:
+        received.append(f"{message.text} {loader}")
+        handled.set()
+
+    dispatcher.message.register(handle_message)
+    return dispatcher
+
+
+@pytest.mark.asyncio
+async def test_webhook_dispatches_posted_update() -> None:
+    received: list[str] = []
+    handled = asyncio.Event()
+    app = build_webhook_app(build_dispatcher(received, handled), Bot(token=TOKEN), "/telegram", "secret", loader="loader")
+
+    async with HttpClient(HttpServer(app)) as client:
+        response = await client.post("/telegram", json=UPDATE, headers={SECRET_HEADER: "secret"})
+        # Updates are dispatched in the background, after the response.
+        await asyncio.wait_for(handled.wait(), 1)
+
+    assert response.status == 200
+    assert received == ["https://youtu.be/dQw4w9WgXcQ loader"]
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize("headers", [{}, {SECRET_HEADER: "wrong"}])
+async def test_webhook_rejects_update_without_secret_token(headers: dict[str, str]) -> None:
+    received: list[str] = []
+    app = build_webhook_app(build_dispatcher(received, asyncio.Event()), Bot(token=TOKEN), "/telegram", "secret", loader="loader")
+
+    async with HttpClient(HttpServer(app)) as client:
+        response = await client.post("/telegram", json=UPDATE, headers=headers)
+
+    assert response.status == 401
+    assert received == []
+
+
+@pytest.mark.asyncio
+async def test_run_webhook_registers_webhook_and_serves_until_cancelled() -> None:
+    settings = build_settings()
+    bot = AsyncMock()
+    dispatcher = MagicMock()
+    dispatcher.resolve_used_update_types.return_value = ["message", "callback_query"]
+    started = asyncio.Event()
+    bot.set_webhook.side_effect = lambda *args, **kwargs: started.set()
+
+    with (
+        patch("src.client.telegram.webhook.build_webhook_app") as mock_build_app,
+        patch("src.client.telegram.webhook.web") as mock_web,
+    ):
+        mock_web.AppRunner.return_value.setup = AsyncMock()
+        mock_web.AppRunner.return_value.cleanup = AsyncMock()
+        mock_web.TCPSite.return_value.start = AsyncMock()
+        task = asyncio.create_task(run_webhook(dispatcher, bot, settings, loader="loader"))
+        await asyncio.wait_for(started.wait(), 1)
+        task.cancel()
+        with pytest.raises(asyncio.CancelledError):
+            await task
+
+    mock_build_app.assert_called_once_with(dispatcher, bot, "/telegram", "secret", settings=settings, loader="loader")
+    mock_web.TCPSite.assert_called_once_with(mock_web.AppRunner.return_value, "0.0.0.0", 8080)
+    bot.set_webhook.assert_awaited_once_with(
+        "https://bot.example.com/telegram", secret_token="secret", allowed_updates=["message", "callback_query"]
+    )
+    mock_web.AppRunner.return_value.cleanup.assert_awaited_once()
+
+
+@pytest.mark.asyncio
+async def test_run_webhook_requires_webhook_url() -> None:
+    with pytest.raises(ValueError, match="TELEGRAM_WEBHOOK_URL"):
+        await run_webhook(MagicMock(), AsyncMock(), build_settings(telegram_webhook_url=None))
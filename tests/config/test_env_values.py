@@ -0,0 +1,260 @@
+import os
+from unittest.mock import MagicMock, patch
+
+import pytest
+from src.config import (
+    DEFAULT_CACHE_COMPRESSION_METHOD,
+    DEFAULT_MAX_TELEGRAM_MESSAGE_LENGTH,
+    DEFAULT_MIN_TRANSCRIPT_CHARS,
+    DEFAULT_OPENAI_TIMEOUT_SECONDS,
+    DEFAULT_PROGRESS_STYLE,
+    DEFAULT_SUBTITLE_FORMAT,
+    DEFAULT_TRANSCRIPT_BACKEND,
+    DEFAULT_TRANSCRIPT_LIMIT_POLICY,
+    DEFAULT_YT_DLP_RETRY_DELAY_SECONDS,
+    TELEGRAM_MAX_MESSAGE_LENGTH,
+    Settings,
+)
+from src.transform.transcript_limit import TranscriptLimitPolicy
+
+
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_compression_method_fallback(mock_load_dotenv: MagicMock) -> None:
+    with patch.dict(
+        os.environ,
+        {
+            "TELEGRAM_BOT_TOKEN": "test_token",
+            "OPENAI_API_KEY": "test_api_key",
+            "OPENAI_MODEL": "gpt-3.5-turbo",
+            "CACHE_COMPRESSION_METHOD": "invalid",
+        },
+        clear=True,
+    ):
+        settings = Settings.from_env()
+
+        assert settings.cache_compression_method == DEFAULT_CACHE_COMPRESSION_METHOD
+
+
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_compression_method_normalized(mock_load_dotenv: MagicMock) -> None:
+    with patch.dict(
+        os.environ,
+        {
+            "TELEGRAM_BOT_TOKEN": "test_token",
+            "OPENAI_API_KEY": "test_api_key",
+            "OPENAI_MODEL": "gpt-3.5-turbo",
+            "CACHE_COMPRESSION_METHOD": "LZMA",
+        },
+        clear=True,
+    ):
+        settings = Settings.from_env()
+
+        assert settings.cache_compression_method == "lzma"
+
+
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_invalid_ints_fall_back(mock_load_dotenv: MagicMock) -> None:
+    with patch.dict(
+        os.environ,
+        {
+            "TELEGRAM_BOT_TOKEN": "test_token",
+            "OPENAI_API_KEY": "test_api_key",
+            "OPENAI_MODEL": "gpt-3.5-turbo",
+            "OPENAI_TIMEOUT_SECONDS": "not-a-number",
+            "MAX_TELEGRAM_MESSAGE_LENGTH": "nope",
+            "YT_DLP_RETRY_DELAY": "soon",
+        },
+        clear=True,
+    ):
+        settings = Settings.from_env()
+
+        assert settings.openai_timeout_seconds == DEFAULT_OPENAI_TIMEOUT_SECONDS
+        assert settings.max_telegram_message_length == DEFAULT_MAX_TELEGRAM_MESSAGE_LENGTH
+        assert settings.yt_dlp_retry_delay_seconds == DEFAULT_YT_DLP_RETRY_DELAY_SECONDS
+
+
+@pytest.mark.parametrize(("value", "expected"), [("sample", "sample"), (" TRUNCATE ", "truncate"), ("random", DEFAULT_TRANSCRIPT_LIMIT_POLICY)])
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_transcript_limit_policy(mock_load_dotenv: MagicMock, value: str, expected: str) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "TRANSCRIPT_LIMIT_POLICY": value,
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.transcript_limit_policy == expected
+
+
+@pytest.mark.parametrize("policy", list(TranscriptLimitPolicy))
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_accepts_every_transcript_limit_policy(mock_load_dotenv: MagicMock, policy: TranscriptLimitPolicy) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "TRANSCRIPT_LIMIT_POLICY": policy.value,
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.transcript_limit_policy == policy.value
+
+
+@pytest.mark.parametrize(("value", "expected"), [("vtt", "vtt"), (" JSON3 ", "json3"), ("ass", DEFAULT_SUBTITLE_FORMAT)])
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_subtitle_format(mock_load_dotenv: MagicMock, value: str, expected: str) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "SUBTITLE_FORMAT": value,
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.subtitle_format == expected
+
+
+@pytest.mark.parametrize(("value", "expected"), [("append", "append"), (" REPLACE ", "replace"), ("spinner", DEFAULT_PROGRESS_STYLE)])
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_progress_style(mock_load_dotenv: MagicMock, value: str, expected: str) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "PROGRESS_STYLE": value,
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.progress_style == expected
+
+
+@pytest.mark.parametrize(("value", "expected"), [("5000", TELEGRAM_MAX_MESSAGE_LENGTH), ("0", 1), ("2048", 2048)])
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_clamps_message_length(mock_load_dotenv: MagicMock, value: str, expected: int) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "MAX_TELEGRAM_MESSAGE_LENGTH": value,
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.max_telegram_message_length == expected
+
+
+@pytest.mark.parametrize(("value", "expected"), [(" Timedtext ", "timedtext"), ("", DEFAULT_TRANSCRIPT_BACKEND)])
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_transcript_backend(mock_load_dotenv: MagicMock, value: str, expected: str) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "TRANSCRIPT_BACKEND": value,
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.transcript_backend == expected
+
+
+
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_summary_header_key(mock_load_dotenv: MagicMock) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "SUMMARY_HEADER_KEY": " telegram.response.title_detailed ",
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.summary_header_key == "telegram.response.title_detailed"
+
+
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_summary_styles(mock_load_dotenv: MagicMock) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "SUMMARY_STYLE": "Bullets",
+        "SUMMARY_STYLE_JA": " detailed ",
+        "SUMMARY_STYLE_DE": "haiku",
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.summary_style == "bullets"
+    assert settings.summary_styles_by_language == {"ja": "detailed"}
+
+
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_chat_access(mock_load_dotenv: MagicMock) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "TELEGRAM_ALLOWED_CHAT_IDS": " 123456789, -1001234567890 ,",
+        "TELEGRAM_ALLOW_GROUPS": "false",
+        "TELEGRAM_REPLY_NOT_ALLOWED": "true",
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.telegram_allowed_chat_ids == (123456789, -1001234567890)
+    assert settings.telegram_allow_groups is False
+    assert settings.telegram_reply_not_allowed is True
+
+
+@pytest.mark.parametrize(("value", "expected"), [("yes", True), ("", False)])
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_group_mention_only(mock_load_dotenv: MagicMock, value: str, expected: bool) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "TELEGRAM_GROUP_MENTION_ONLY": value,
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.telegram_group_mention_only is expected
+
+
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_model_switching(mock_load_dotenv: MagicMock) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "OPENAI_MODELS": "gpt-4o, gpt-4o-mini,",
+        "TELEGRAM_ADMIN_IDS": "123456789, 987654321",
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.openai_models == ("gpt-4o", "gpt-4o-mini")
+    assert settings.telegram_admin_ids == (123456789, 987654321)
+
+
+@pytest.mark.parametrize(("value", "expected"), [("250", 250), ("0", 0), ("-5", 0), ("many", DEFAULT_MIN_TRANSCRIPT_CHARS)])
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_min_transcript_chars(mock_load_dotenv: MagicMock, value: str, expected: int) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "MIN_TRANSCRIPT_CHARS": value,
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.min_transcript_chars == expected
+
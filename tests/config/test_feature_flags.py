@@ -0,0 +1,92 @@
+import os
+from unittest.mock import MagicMock, patch
+
+import pytest
+from src.config import FeatureFlags, Settings
+
+
+@pytest.mark.parametrize(
+    ("value", "expected"),
+    [
+        ("true", True),
+        ("TRUE", True),
+        ("1", True),
+        ("yes", True),
+        ("on", True),
+        ("false", False),
+        ("False", False),
+        ("0", False),
+        ("no", False),
+        ("off", False),
+        ("maybe", True),
+        ("", True),
+    ],
+)
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_feature_flags(mock_load_dotenv: MagicMock, value: str, expected: bool) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "ENABLE_STYLE_KEYBOARD": value,
+        "ENABLE_LINK_PREVIEW": value,
+        "ENABLE_ROLLING_CAPTION_MERGE": value,
+        "ENABLE_SUMMARY_CLEANUP": value,
+        "ENABLE_SUMMARY_HEADER": value,
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    # Unrecognized values fall back to the default (enabled)
+    assert settings.feature_flags == FeatureFlags(
+        style_keyboard=expected,
+        link_preview=expected,
+        merge_rolling_captions=expected,
+        summary_cleanup=expected,
+        summary_header=expected,
+    )
+
+
+@pytest.mark.parametrize(("value", "expected"), [("true", True), ("false", False), ("maybe", False)])
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_chapter_summaries_flag(mock_load_dotenv: MagicMock, value: str, expected: bool) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "ENABLE_CHAPTER_SUMMARIES": value,
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    # Chapter summaries cost one LLM request per chapter, so unrecognized values keep them off
+    assert settings.feature_flags.chapter_summaries is expected
+
+
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_word_timings_flag(mock_load_dotenv: MagicMock) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "ENABLE_WORD_TIMINGS": "true",
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.feature_flags.word_timings is True
+
+
+@patch("src.config.settings.load_dotenv")
+def test_settings_from_env_short_transcript_text_flag(mock_load_dotenv: MagicMock) -> None:
+    env = {
+        "TELEGRAM_BOT_TOKEN": "test_token",
+        "OPENAI_API_KEY": "test_api_key",
+        "OPENAI_MODEL": "gpt-3.5-turbo",
+        "ENABLE_SHORT_TRANSCRIPT_TEXT": "true",
+    }
+    with patch.dict(os.environ, env, clear=True):
+        settings = Settings.from_env()
+
+    assert settings.feature_flags.short_transcript_text is True
+
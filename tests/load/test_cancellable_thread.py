@@ -0,0 +1,38 @@
+import asyncio
+import threading
+
+import pytest
+from src.load.cancellable_thread import run_cancellable
+
+
+@pytest.mark.asyncio
+async def test_run_cancellable_returns_result() -> None:
+    assert await run_cancellable(lambda cancelled: not cancelled.is_set()) is True
+
+
+@pytest.mark.asyncio
+async def test_run_cancellable_propagates_errors() -> None:
+    def fail(cancelled: threading.Event) -> None:
+        raise ValueError("boom")
+
+    with pytest.raises(ValueError, match="boom"):
+        await run_cancellable(fail)
+
+
+@pytest.mark.asyncio
+async def test_run_cancellable_signals_cancellation() -> None:
+    started = threading.Event()
+    saw_cancel = threading.Event()
+
+    def block(cancelled: threading.Event) -> None:
+        started.set()
+        if cancelled.wait(5):
+            saw_cancel.set()
+
+    task = asyncio.create_task(run_cancellable(block))
+    assert await asyncio.to_thread(started.wait, 5)
+    task.cancel()
+    with pytest.raises(asyncio.CancelledError):
+        await task
+
+    assert await asyncio.to_thread(saw_cancel.wait, 5)
@@ -0,0 +1,87 @@
+import json
+
+from src.load.chapters import Chapter, parse_chapters, split_transcript_by_chapters
+
+CHAPTERS = (Chapter("Intro", 0.0, 60.0), Chapter("Setup", 60.0, 3600.0), Chapter("Wrap-up", 3600.0, None))
+
+CHAPTERED_SRT = """1
+00:00:01,000 --> 00:00:04,000
+Welcome to the show
+
+2
+00:00:58,500 --> 00:01:02,000
+Let's install everything
+
+3
+00:30:00,000 --> 00:30:03,000
+Run the installer
+
+4
+01:00:05,000 --> 01:00:08,000
+Thanks for watching
+"""
+
+CHAPTERED_VTT = """WEBVTT
+Kind: captions
+Language: en
+
+00:01.000 --> 00:04.000 align:start position:0%
+Welcome to the show
+
+01:02.000 --> 01:05.000
+Let's install everything
+"""
+
+
+def test_parse_chapters() -> None:
+    raw = [
+        {"title": "Setup", "start_time": 60, "end_time": 3600},
+        {"title": " Intro ", "start_time": 0.0, "end_time": 60.0},
+        {"title": "Broken"},
+        "not a chapter",
+        {"start_time": 3600.0},
+    ]
+
+    assert parse_chapters(raw) == (Chapter("Intro", 0.0, 60.0), Chapter("Setup", 60.0, 3600.0), Chapter("", 3600.0, None))
+
+
+def test_parse_chapters_without_chapters() -> None:
+    assert parse_chapters(None) == ()
+    assert parse_chapters([]) == ()
+
+
+def test_split_srt_by_chapters() -> None:
+    chapters = split_transcript_by_chapters(CHAPTERED_SRT, False, CHAPTERS, merge_rolling=False)
+
+    assert [chapter.transcript for chapter in chapters] == [
+        "Welcome to the show Let's install everything",
+        "Run the installer",
+        "Thanks for watching",
+    ]
+    assert [chapter.title for chapter in chapters] == ["Intro", "Setup", "Wrap-up"]
+
+
+def test_split_vtt_by_chapters() -> None:
+    chapters = split_transcript_by_chapters(CHAPTERED_VTT, False, CHAPTERS[:2], merge_rolling=False)
+
+    assert [chapter.transcript for chapter in chapters] == ["Welcome to the show", "Let's install everything"]
+
+
+def test_split_json3_by_chapters() -> None:
+    raw = json.dumps(
+        {
+            "events": [
+                {"tStartMs": 1000, "segs": [{"utf8": "Welcome to the show"}]},
+                {"tStartMs": 1_800_000, "segs": [{"utf8": "Run the installer"}]},
+                {"tStartMs": 3_605_000, "segs": [{"utf8": "Thanks for watching"}]},
+            ]
+        }
+    )
+
+    chapters = split_transcript_by_chapters(raw, True, CHAPTERS, merge_rolling=False)
+
+    assert [chapter.transcript for chapter in chapters] == ["Welcome to the show", "Run the installer", "Thanks for watching"]
+
+
+def test_split_without_chapters() -> None:
+    assert split_transcript_by_chapters(CHAPTERED_SRT, False, (), merge_rolling=False) == ()
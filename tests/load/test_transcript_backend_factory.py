@@ -0,0 +1,36 @@
+import threading
+from unittest.mock import MagicMock, patch
+
+import pytest
+from src.config import Settings
+from src.load.transcript_backend import RawSubtitles, TranscriptBackend
+from src.load.transcript_backend_factory import get_transcript_backend, register_transcript_backend
+from src.load.yt_dlp_backend import YtDlpTranscriptBackend
+
+
+class FakeBackend(TranscriptBackend):
+    def fetch(self, url: str, video_id: str, language: str, has_manual_subtitles: bool, cancelled: threading.Event) -> RawSubtitles | None:
+        return None
+
+
+def build_settings(transcript_backend: str) -> Settings:
+    settings = MagicMock(spec=Settings)
+    settings.yt_dlp_proxies = ()
+    settings.transcript_backend = transcript_backend
+    return settings
+
+
+def test_get_transcript_backend_defaults_to_yt_dlp() -> None:
+    assert isinstance(get_transcript_backend(build_settings("yt-dlp")), YtDlpTranscriptBackend)
+
+
+def test_register_transcript_backend() -> None:
+    with patch.dict("src.load.transcript_backend_factory._factories"):
+        register_transcript_backend(" Fake ", lambda settings: FakeBackend())
+
+        assert isinstance(get_transcript_backend(build_settings("fake")), FakeBackend)
+
+
+def test_get_transcript_backend_unknown_name() -> None:
+    with pytest.raises(ValueError, match="unknown transcript backend: missing"):
+        get_transcript_backend(build_settings("missing"))
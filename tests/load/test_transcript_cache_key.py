@@ -0,0 +1,69 @@
+from unittest.mock import MagicMock, patch
+
+from src.config import FeatureFlags, Settings
+from src.load.transcript_cache_key import transcript_cache_key
+from src.load.transcripts import cleaning_version
+
+URL = "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+
+
+def build_settings(**overrides: object) -> Settings:
+    settings = MagicMock(spec=Settings)
+    settings.subtitle_format = "srt"
+    settings.prefer_manual_subtitles = False
+    settings.transcript_backend = "yt-dlp"
+    settings.feature_flags = FeatureFlags()
+    for key, value in overrides.items():
+        setattr(settings, key, value)
+    return settings
+
+
+def test_transcript_cache_key_depends_on_url() -> None:
+    settings = build_settings()
+
+    assert transcript_cache_key(URL, settings) == transcript_cache_key(URL, settings)
+    assert transcript_cache_key(URL, settings) != transcript_cache_key("https://www.youtube.com/watch?v=abcdefghijk", settings)
+
+
+def test_transcript_cache_key_depends_on_cleaning_options() -> None:
+    merging_key = transcript_cache_key(URL, build_settings(feature_flags=FeatureFlags(merge_rolling_captions=True)))
+    plain_key = transcript_cache_key(URL, build_settings(feature_flags=FeatureFlags(merge_rolling_captions=False)))
+
+    assert merging_key != plain_key
+    assert merging_key == transcript_cache_key(URL, build_settings(feature_flags=FeatureFlags()))
+
+
+def test_transcript_cache_key_depends_on_subtitle_format() -> None:
+    srt_key = transcript_cache_key(URL, build_settings(subtitle_format="srt"))
+    json3_key = transcript_cache_key(URL, build_settings(subtitle_format="json3"))
+
+    assert srt_key != json3_key
+
+
+def test_transcript_cache_key_depends_on_word_timings() -> None:
+    plain_key = transcript_cache_key(URL, build_settings())
+    words_key = transcript_cache_key(URL, build_settings(feature_flags=FeatureFlags(word_timings=True)))
+
+    assert plain_key != words_key
+
+
+def test_transcript_cache_key_depends_on_prefer_manual_subtitles() -> None:
+    any_key = transcript_cache_key(URL, build_settings(prefer_manual_subtitles=False))
+    manual_key = transcript_cache_key(URL, build_settings(prefer_manual_subtitles=True))
+
+    assert any_key != manual_key
+
+
+def test_transcript_cache_key_depends_on_transcript_backend() -> None:
+    yt_dlp_key = transcript_cache_key(URL, build_settings(transcript_backend="yt-dlp"))
+    canned_key = transcript_cache_key(URL, build_settings(transcript_backend="canned"))
+
+    assert yt_dlp_key != canned_key
+
+
+def test_transcript_cache_key_depends_on_cleaning_version() -> None:
+    settings = build_settings()
+    key = transcript_cache_key(URL, settings)
+
+    with patch("src.load.transcript_cache_key.cleaning_version", cleaning_version + 1):
+        assert transcript_cache_key(URL, settings) != key
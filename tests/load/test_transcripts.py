@@ -1,20 +1,7 @@
-from pathlib import Path
-from unittest.mock import MagicMock, patch
+import json
 
-from src.config import Settings
-from src.load.transcripts import clean_srt
-from src.load.video_loader import VideoDataLoader
-
-
-def build_settings(**overrides: object) -> Settings:
-    settings = MagicMock(spec=Settings)
-    settings.yt_dlp_additional_options = ()
-    settings.cache_transcript_ttl_seconds = 3600
-    settings.valkey_url = None
-    settings.cache_compression_method = "gzip"
-    for key, value in overrides.items():
-        setattr(settings, key, value)
-    return settings
+import pytest
+from src.load.transcripts import clean_json3, clean_srt, normalize_text
 
 
 def test_clean_srt_basic() -> None:
@@ -37,6 +24,103 @@ Another line\\h
     assert clean_srt(text) == "Some text Another line"
 
 
+def test_clean_srt_merges_rolling_auto_captions() -> None:
+    text = """1
+00:00:00,000 --> 00:00:01,000
+the quick
+
+2
+00:00:01,000 --> 00:00:02,000
+the quick brown
+
+3
+00:00:02,000 --> 00:00:03,000
+quick brown fox
+
+4
+00:00:03,000 --> 00:00:04,000
+brown fox jumps over
+"""
+    assert clean_srt(text, merge_rolling=True) == "the quick brown fox jumps over"
+
+
+def test_clean_srt_merges_rolling_youtube_vtt() -> None:
+    text = """WEBVTT
+Kind: captions
+Language: en
+
+00:00:00.160 --> 00:00:02.310 align:start position:0%
+so<00:00:00.400><c> today</c><00:00:00.640><c> we're</c>
+
+00:00:02.310 --> 00:00:04.470 align:start position:0%
+today we're going to<00:00:02.800><c> talk</c>
+
+00:00:04.470 --> 00:00:06.150 align:start position:0%
+going to talk about<00:00:04.880><c> caching</c>
+"""
+    assert clean_srt(text, merge_rolling=True) == "so today we're going to talk about caching"
+
+
+def test_clean_srt_keeps_word_repeated_across_rolling_lines() -> None:
+    text = """1
+00:00:00,000 --> 00:00:01,000
+and she said that
+
+2
+00:00:01,000 --> 00:00:02,000
+that was the plan
+"""
+    assert clean_srt(text, merge_rolling=True) == "and she said that that was the plan"
+
+
+def test_clean_srt_merges_single_word_rolling_line() -> None:
+    text = """1
+00:00:00,000 --> 00:00:01,000
+so
+
+2
+00:00:01,000 --> 00:00:02,000
+so today we
+"""
+    assert clean_srt(text, merge_rolling=True) == "so today we"
+
+
+def test_clean_srt_keeps_rolling_lines_by_default() -> None:
+    text = """1
+00:00:00,000 --> 00:00:01,000
+the quick
+
+2
+00:00:01,000 --> 00:00:02,000
+the quick brown
+"""
+    assert clean_srt(text) == "the quick the quick brown"
+
+
+def test_normalize_text_unescapes_html_entities() -> None:
+    assert normalize_text("Tom &amp; Jerry don&#39;t &quot;talk&quot; &lt;loud&gt;") == 'Tom & Jerry don\'t "talk" <loud>'
+
+
+def test_normalize_text_collapses_whitespace() -> None:
+    assert normalize_text("  Hello  \t world \n\n again\u00a0 ") == "Hello world again"
+
+
+def test_normalize_text_removes_zero_width_and_control_chars() -> None:
+    assert normalize_text("\ufeffzero\u200bwidth\u2060 text\x00\x07") == "zerowidth text"
+
+
+def test_clean_srt_normalizes_output() -> None:
+    text = """1
+00:00:00,000 --> 00:00:01,000
+Rock &amp; roll\u200b
+
+2
+00:00:01,000 --> 00:00:02,000
+isn&#39;t  dead
+"""
+    assert clean_srt(text) == "Rock & roll isn't dead"
+
+
 def test_clean_srt_removes_numeric_lines() -> None:
     text = """1
 00:00:01,000 --> 00:00:04,000
@@ -138,33 +222,6 @@ Single line"""
     assert clean_srt(text) == "Multi line subtitle Single line"
 
 
-def test_find_subtitle_file_exact_match() -> None:
-    with patch("tempfile.gettempdir") as mock_temp:
-        mock_temp.return_value = "/tmp"
-
-        loader = VideoDataLoader(build_settings())
-
-        with patch("tempfile.gettempdir", return_value="/tmp"):
-            with patch.object(Path, "exists", return_value=True):
-                result = loader._find_subtitle_file("test", "en")
-                expected_path = Path("/tmp") / "subtitles_test.en.srt"
-                assert result == expected_path
-
-
-def test_find_subtitle_file_vtt_fallback() -> None:
-    """When .srt does not exist, the loader should fall back to .vtt."""
-    with patch("tempfile.gettempdir", return_value="/tmp"):
-        loader = VideoDataLoader(build_settings())
-
-        def exists_only_vtt(self: Path) -> bool:
-            return str(self).endswith(".en.vtt")
-
-        with patch.object(Path, "exists", exists_only_vtt):
-            result = loader._find_subtitle_file("test", "en")
-            assert result is not None
-            assert str(result).endswith(".en.vtt")
-
-
 def test_clean_srt_handles_various_special_endings() -> None:
     text = """1
 00:00:00,000 --> 00:00:00,001
@@ -178,3 +235,30 @@ Text with\\c"""
     result = clean_srt(text)
     # The function removes duplicates, so we expect only one "Text with"
     assert result == "Text with"
+
+
+def test_clean_json3_joins_segments() -> None:
+    text = json.dumps(
+        {
+            "events": [
+                {"tStartMs": 0, "segs": [{"utf8": "the "}, {"utf8": "quick", "tOffsetMs": 200}]},
+                {"tStartMs": 900, "segs": [{"utf8": "\n"}]},
+                {"tStartMs": 1000},
+                {"tStartMs": 1200, "segs": [{"utf8": "brown &amp; fox"}]},
+                {"tStartMs": 1500, "segs": [{"utf8": "brown &amp; fox"}]},
+            ]
+        }
+    )
+
+    assert clean_json3(text) == "the quick brown & fox"
+
+
+def test_clean_json3_merges_rolling_captions() -> None:
+    text = json.dumps({"events": [{"segs": [{"utf8": "the quick brown"}]}, {"segs": [{"utf8": "quick brown fox"}]}]})
+
+    assert clean_json3(text, merge_rolling=True) == "the quick brown fox"
+
+
+@pytest.mark.parametrize("text", ["not json", "[]", "{}"])
+def test_clean_json3_invalid_content(text: str) -> None:
+    assert clean_json3(text) == ""
@@ -0,0 +1,100 @@
+import pytest
+from src.load.url_extraction import ProviderMatch, extract_all_urls, extract_canonical_urls, extract_urls
+from src.load.video_provider import VKVIDEO, YOUTUBE, YOUTUBE_SHORT
+
+
+def test_extract_urls_youtube() -> None:
+    text = "text https://youtu.be/validID1234 and https://www.youtube.com/watch?v=anotherID12"
+    assert extract_urls(text) == [
+        "https://youtu.be/validID1234",
+        "https://www.youtube.com/watch?v=anotherID12",
+    ]
+
+
+def test_extract_urls_youtube_full_urls() -> None:
+    text = "Check out https://www.youtube.com/watch?v=dQw4w9WgXcQ and https://youtube.com/watch?v=abc123def45"
+    assert extract_urls(text) == [
+        "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+        "https://youtube.com/watch?v=abc123def45",
+    ]
+
+
+def test_extract_urls_youtube_with_params() -> None:
+    text = "Video: https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=1s&ab_channel=Channel"
+    result = extract_urls(text)
+    # The extract_urls function extracts the full URLs that match the pattern
+    assert len(result) == 1
+    # The result should be the full URL with parameters
+    assert result[0] == "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+
+
+def test_extract_urls_youtube_shorts() -> None:
+    text = "Short: https://www.youtube.com/shorts/abcdefghijk and https://youtube.com/shorts/xyz123"
+    extracted = extract_urls(text)
+    # Only the first one should match since the pattern doesn't include non-www youtube.com/shorts
+    assert len(extracted) == 1
+    assert extracted[0] == "https://www.youtube.com/shorts/abcdefghijk"
+
+
+def test_extract_urls_vkvideo() -> None:
+    text = "VK: https://vkvideo.ru/video-123456_789012 and http://www.vkvideo.ru/video-987654_321098"
+    assert extract_urls(text) == [
+        "https://vkvideo.ru/video-123456_789012",
+        "http://www.vkvideo.ru/video-987654_321098",
+    ]
+
+
+def test_extract_urls_mixed_providers() -> None:
+    # The extract_urls function returns URLs from the first provider that matches in the text
+    # Since PROVIDERS = (YOUTUBE, YOUTUBE_SHORT, VKVIDEO), it will match YouTube URLs first
+    # Using proper 11-character IDs
+    text = "YouTube: https://youtu.be/abcdefghijk, Shorts: https://www.youtube.com/shorts/klmnopqrstu, VK: https://vkvideo.ru/video-123_456"
+    extracted = extract_urls(text)
+    # Should find YouTube URLs first since YOUTUBE is the first provider in PROVIDERS
+    assert len(extracted) == 1
+    assert extracted[0] == "https://youtu.be/abcdefghijk"
+
+
+@pytest.mark.parametrize(
+    ("text", "expected_urls"),
+    [
+        (
+            "https://youtu.be/dQw4w9WgXcQ and again https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=42",
+            ["https://www.youtube.com/watch?v=dQw4w9WgXcQ"],
+        ),
+        (
+            "m.youtube.com/watch?v=abcdefghijk https://youtu.be/dQw4w9WgXcQ https://youtube.com/live/abcdefghijk",
+            ["https://www.youtube.com/watch?v=abcdefghijk", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"],
+        ),
+        (
+            "http://www.vkvideo.ru/video-123_456 vkvideo.ru/video-123_456",
+            ["https://vkvideo.ru/video-123_456"],
+        ),
+        ("no links here", []),
+    ],
+)
+def test_extract_canonical_urls_deduplicates_links_to_the_same_video(text: str, expected_urls: list[str]) -> None:
+    assert extract_canonical_urls(text) == expected_urls
+
+
+def test_extract_all_urls_tags_every_provider_in_text_order() -> None:
+    text = (
+        "VK: https://vkvideo.ru/video-123_456, Shorts: https://www.youtube.com/shorts/klmnopqrstu, "
+        "YouTube: https://youtu.be/abcdefghijk, again: https://www.youtube.com/watch?v=abcdefghijk"
+    )
+
+    assert extract_all_urls(text) == [
+        ProviderMatch(VKVIDEO, "https://vkvideo.ru/video-123_456", "video-123_456", 4),
+        ProviderMatch(YOUTUBE_SHORT, "https://www.youtube.com/shorts/klmnopqrstu", "klmnopqrstu", 46),
+        ProviderMatch(YOUTUBE, "https://www.youtube.com/watch?v=abcdefghijk", "abcdefghijk", 99),
+    ]
+
+
+def test_extract_urls_no_urls() -> None:
+    text = "This text contains no video URLs"
+    assert extract_urls(text) == []
+
+
+def test_extract_urls_invalid_urls() -> None:
+    text = "Invalid: https://youtube.com/invalid, https://youtu.be/, https://vkvideo.ru/"
+    assert extract_urls(text) == []
@@ -1,17 +1,36 @@
+import asyncio
+import json
+import threading
+from dataclasses import asdict, replace
 from pathlib import Path
-from unittest.mock import MagicMock, patch
+from unittest.mock import ANY, AsyncMock, MagicMock, patch
 
 import pytest
-from src.config import Settings
-from src.load.video_loader import VideoDataLoader, VideoInfo, VideoTranscript, _is_safe_option_value
+from src.config import FeatureFlags, Settings
+from src.load.chapters import Chapter
+from src.load.errors import NoSubtitlesError, VideoUnavailableError
+from src.load.transcript_backend import FetchCancelledError, RawSubtitles, TranscriptBackend
+from src.load.transcript_backend_factory import register_transcript_backend
+from src.load.video_loader import VideoDataLoader, VideoInfo, VideoTranscript
+from src.load.word_timings import WordTiming
+from src.load.yt_dlp_backend import YtDlpTranscriptBackend
 
 
 def build_settings(**overrides: object) -> Settings:
     settings = MagicMock(spec=Settings)
     settings.yt_dlp_additional_options = ()
+    settings.yt_dlp_proxies = ()
+    settings.yt_dlp_allow_any_options = False
     settings.cache_transcript_ttl_seconds = 3600
     settings.valkey_url = None
     settings.cache_compression_method = "gzip"
+    settings.yt_dlp_max_attempts = 3
+    settings.yt_dlp_retry_delay_seconds = 0
+    settings.yt_dlp_cookies_file = None
+    settings.subtitle_format = "srt"
+    settings.prefer_manual_subtitles = False
+    settings.transcript_backend = "yt-dlp"
+    settings.feature_flags = FeatureFlags()
     for key, value in overrides.items():
         setattr(settings, key, value)
     return settings
@@ -69,26 +88,6 @@ def test_video_data_loader_initialization_with_options() -> None:
     assert loader.yt_dlp_additional_options == ("--format", "mp4")
 
 
-def test_video_data_loader_subtitle_template_path() -> None:
-    with patch("tempfile.gettempdir") as mock_temp:
-        mock_temp.return_value = "/tmp"
-
-        loader = VideoDataLoader(build_settings())
-        expected_path = "/tmp/subtitles_test.%(ext)s"
-
-        assert loader._get_subtitle_template_path("test") == expected_path
-
-
-def test_video_data_loader_subtitle_prefix() -> None:
-    with patch("tempfile.gettempdir") as mock_temp:
-        mock_temp.return_value = "/tmp"
-
-        loader = VideoDataLoader(build_settings())
-        expected_prefix = Path("/tmp/subtitles_test")
-
-        assert loader._get_subtitle_prefix("test") == expected_prefix
-
-
 def test_detect_language_with_video_info_language() -> None:
     loader = VideoDataLoader(build_settings())
 
@@ -150,23 +149,10 @@ def test_detect_language_no_subtitles_or_language() -> None:
     )
 
     # Should raise RuntimeError when no subtitles available
-    with pytest.raises(RuntimeError, match="no subtitles available"):
+    with pytest.raises(NoSubtitlesError, match="no subtitles available"):
         loader._detect_language(info)
 
 
-def test_find_subtitle_file_exact_match() -> None:
-    with patch("tempfile.gettempdir") as mock_temp:
-        mock_temp.return_value = "/tmp"
-
-        loader = VideoDataLoader(build_settings())
-
-        with patch("tempfile.gettempdir", return_value="/tmp"):
-            with patch.object(Path, "exists", return_value=True):
-                result = loader._find_subtitle_file("test", "en")
-                expected_path = Path("/tmp") / "subtitles_test.en.srt"
-                assert result == expected_path
-
-
 def test_build_ydl_opts_base_options() -> None:
     loader = VideoDataLoader(build_settings())
     opts = loader._build_ydl_opts()
@@ -194,6 +180,21 @@ def test_build_ydl_opts_with_user_options() -> None:
     assert opts["cookies"] == "cookies.txt"
 
 
+def test_build_ydl_opts_rotates_proxies() -> None:
+    loader = VideoDataLoader(build_settings(yt_dlp_proxies=("http://proxy-a:8080", "socks5://proxy-b:1080")))
+
+    proxies = [loader._build_ydl_opts()["proxy"] for _ in range(3)]
+
+    assert proxies == ["http://proxy-a:8080", "socks5://proxy-b:1080", "http://proxy-a:8080"]
+
+
+def test_build_ydl_opts_with_cookies_file() -> None:
+    loader = VideoDataLoader(build_settings(yt_dlp_cookies_file="/etc/briefly/cookies.txt"))
+    opts = loader._build_ydl_opts({"dumpjson": True})
+
+    assert opts["cookiefile"] == "/etc/briefly/cookies.txt"
+
+
 @patch("yt_dlp.YoutubeDL")
 def test_load_success(mock_youtube_dl_class: MagicMock) -> None:
     # Mock the context manager
@@ -221,7 +222,7 @@ def test_load_success(mock_youtube_dl_class: MagicMock) -> None:
     mock_subtitle_file.read_text.return_value = "1\n00:00:00,000 --> 00:00:01,000\nTest subtitle"
     mock_subtitle_file.exists.return_value = True
 
-    with patch.object(VideoDataLoader, "_find_subtitle_file", return_value=mock_subtitle_file):
+    with patch.object(YtDlpTranscriptBackend, "_find_subtitle_file", return_value=mock_subtitle_file):
         with patch("src.load.video_loader.clean_srt", return_value="Test subtitle"):
             loader = VideoDataLoader(build_settings())
             transcript = loader._load("https://youtu.be/test", "test")
@@ -231,6 +232,176 @@ def test_load_success(mock_youtube_dl_class: MagicMock) -> None:
             assert transcript.transcript == "Test subtitle"
 
 
+@pytest.mark.parametrize(
+    ("subtitles", "expected_merge"),
+    [
+        ({}, True),  # only auto-generated captions available
+        ({"en": []}, False),  # manual subtitles in the selected language
+        ({"en-GB": []}, False),  # regional manual subtitles in the selected language
+    ],
+)
+@patch("yt_dlp.YoutubeDL")
+def test_load_merges_rolling_lines_only_for_auto_captions(
+    mock_youtube_dl_class: MagicMock, subtitles: dict[str, list[dict[str, str]]], expected_merge: bool
+) -> None:
+    mock_ydl = MagicMock()
+    mock_ydl.__enter__ = MagicMock(return_value=mock_ydl)
+    mock_ydl.__exit__ = MagicMock(return_value=False)
+    mock_youtube_dl_class.return_value = mock_ydl
+    mock_ydl.extract_info.side_effect = [
+        {"id": "test_id", "language": "en", "uploader": "", "title": "", "thumbnail": "", "subtitles": subtitles},
+        None,
+    ]
+
+    mock_subtitle_file = MagicMock()
+    mock_subtitle_file.read_text.return_value = "raw subtitles"
+
+    with (
+        patch.object(YtDlpTranscriptBackend, "_find_subtitle_file", return_value=mock_subtitle_file),
+        patch("src.load.video_loader.clean_srt", return_value="Test subtitle") as mock_clean_srt,
+    ):
+        loader = VideoDataLoader(build_settings(feature_flags=FeatureFlags()))
+        loader._load("https://youtu.be/test", "test")
+
+    mock_clean_srt.assert_called_once_with("raw subtitles", merge_rolling=expected_merge)
+
+
+@pytest.mark.parametrize(
+    ("subtitles", "found_files", "expected_automatic_calls"),
+    [
+        ({"en": []}, [Path("/tmp/subtitles_test.en.srt")], [False]),  # manual subtitles present
+        ({}, [Path("/tmp/subtitles_test.en_auto.srt")], [True]),  # only auto-generated captions
+        ({"en": []}, [None, None], [False, True]),  # listed manual subtitles missing, auto-generated missing too
+    ],
+)
+@patch("yt_dlp.YoutubeDL")
+def test_load_prefers_manual_subtitles(
+    mock_youtube_dl_class: MagicMock,
+    subtitles: dict[str, list[dict[str, str]]],
+    found_files: list[Path | None],
+    expected_automatic_calls: list[bool],
+) -> None:
+    mock_ydl = MagicMock()
+    mock_ydl.__enter__ = MagicMock(return_value=mock_ydl)
+    mock_ydl.__exit__ = MagicMock(return_value=False)
+    mock_youtube_dl_class.return_value = mock_ydl
+    mock_ydl.extract_info.side_effect = [
+        {"id": "test_id", "language": "en", "uploader": "", "title": "", "thumbnail": "", "subtitles": subtitles},
+        None,
+        None,
+    ]
+
+    with (
+        patch.object(YtDlpTranscriptBackend, "_find_subtitle_file", side_effect=found_files),
+        patch.object(Path, "read_text", return_value="raw subtitles"),
+        patch.object(YtDlpTranscriptBackend, "_cleanup_subtitle_files"),
+        patch("src.load.video_loader.clean_srt", return_value="Test subtitle"),
+    ):
+        loader = VideoDataLoader(build_settings(feature_flags=FeatureFlags(), prefer_manual_subtitles=True))
+        if found_files[-1] is None:
+            with pytest.raises(NoSubtitlesError, match="no subtitles found"):
+                loader._load("https://youtu.be/test", "test")
+        else:
+            assert loader._load("https://youtu.be/test", "test").transcript == "Test subtitle"
+
+    download_options = [call.args[0] for call in mock_youtube_dl_class.call_args_list if "writesubtitles" in call.args[0]]
+    assert [options["writeautomaticsub"] for options in download_options] == expected_automatic_calls
+
+
+@pytest.mark.parametrize(
+    ("file_name", "expected_cleaner"),
+    [
+        ("subtitles_test.en.srt", "clean_srt"),
+        ("subtitles_test.en.vtt", "clean_srt"),
+        ("subtitles_test.en.json3", "clean_json3"),
+    ],
+)
+@patch("yt_dlp.YoutubeDL")
+def test_load_cleans_subtitles_by_format(mock_youtube_dl_class: MagicMock, tmp_path: Path, file_name: str, expected_cleaner: str) -> None:
+    mock_ydl = MagicMock()
+    mock_ydl.__enter__ = MagicMock(return_value=mock_ydl)
+    mock_ydl.__exit__ = MagicMock(return_value=False)
+    mock_youtube_dl_class.return_value = mock_ydl
+    mock_ydl.extract_info.side_effect = [
+        {"id": "test_id", "language": "en", "uploader": "", "title": "", "thumbnail": "", "subtitles": {"en": []}},
+        None,
+    ]
+    subtitle_file = tmp_path / file_name
+    subtitle_file.write_text("raw subtitles", encoding="utf-8")
+
+    with (
+        patch.object(YtDlpTranscriptBackend, "_find_subtitle_file", return_value=subtitle_file),
+        patch("src.load.video_loader.clean_srt", return_value="srt text") as mock_clean_srt,
+        patch("src.load.video_loader.clean_json3", return_value="json3 text") as mock_clean_json3,
+    ):
+        loader = VideoDataLoader(build_settings(feature_flags=FeatureFlags()))
+        loader._load("https://youtu.be/test", "test")
+
+    cleaners = {"clean_srt": mock_clean_srt, "clean_json3": mock_clean_json3}
+    cleaners.pop(expected_cleaner).assert_called_once_with("raw subtitles", merge_rolling=False)
+    for cleaner in cleaners.values():
+        cleaner.assert_not_called()
+
+
+@pytest.mark.asyncio
+@patch("yt_dlp.YoutubeDL")
+async def test_load_vkvideo_transcript(mock_youtube_dl_class: MagicMock) -> None:
+    mock_ydl = MagicMock()
+    mock_ydl.__enter__ = MagicMock(return_value=mock_ydl)
+    mock_ydl.__exit__ = MagicMock(return_value=False)
+    mock_youtube_dl_class.return_value = mock_ydl
+    mock_ydl.extract_info.side_effect = [
+        {
+            "id": "-123456_789012",
+            "language": "ru",
+            "uploader": "VK uploader",
+            "title": "VK title",
+            "thumbnail": "https://vkvideo.ru/thumb.jpg",
+            "subtitles": {"ru": []},
+        },
+        None,
+    ]
+
+    mock_subtitle_file = MagicMock()
+    mock_subtitle_file.read_text.return_value = "1\n00:00:00,000 --> 00:00:01,000\nПривет"
+
+    with (
+        patch.object(YtDlpTranscriptBackend, "_find_subtitle_file", return_value=mock_subtitle_file),
+        patch.object(YtDlpTranscriptBackend, "_cleanup_subtitle_files"),
+        patch("src.load.video_loader.clean_srt", return_value="Привет"),
+    ):
+        loader = VideoDataLoader(build_settings(feature_flags=FeatureFlags()))
+        loader.cache_provider = AsyncMock()
+        loader.cache_provider.get_dict.return_value = None
+        transcript = await loader.load("Watch http://www.vkvideo.ru/video-123456_789012?list=abc")
+
+    assert transcript == VideoTranscript(
+        id="-123456_789012",
+        language="ru",
+        uploader="VK uploader",
+        title="VK title",
+        thumbnail="https://vkvideo.ru/thumb.jpg",
+        transcript="Привет",
+    )
+    assert [call.args[0] for call in mock_ydl.extract_info.call_args_list] == ["https://vkvideo.ru/video-123456_789012"] * 2
+    loader.cache_provider.put_dict.assert_called_once()
+
+
+@pytest.mark.asyncio
+async def test_load_refresh_bypasses_and_replaces_cached_transcript() -> None:
+    transcript = VideoTranscript(id="dQw4w9WgXcQ", language="en", uploader="uploader", title="title", thumbnail="", transcript="new")
+    loader = VideoDataLoader(build_settings())
+    loader.cache_provider = AsyncMock()
+    loader.cache_provider.get_dict.return_value = asdict(replace(transcript, transcript="old"))
+
+    with patch.object(loader, "_load", return_value=transcript):
+        result = await loader.load("https://youtu.be/dQw4w9WgXcQ", refresh=True)
+
+    assert result == transcript
+    loader.cache_provider.get_dict.assert_not_called()
+    loader.cache_provider.put_dict.assert_called_once_with(ANY, asdict(transcript), 3600)
+
+
 @patch("yt_dlp.YoutubeDL")
 def test_load_retry_on_info_failure(mock_youtube_dl_class: MagicMock) -> None:
     # Mock the context manager
@@ -259,7 +430,7 @@ def test_load_retry_on_info_failure(mock_youtube_dl_class: MagicMock) -> None:
     mock_subtitle_file.read_text.return_value = "1\n00:00:00,000 --> 00:00:01,000\nTest subtitle"
     mock_subtitle_file.exists.return_value = True
 
-    with patch.object(VideoDataLoader, "_find_subtitle_file", return_value=mock_subtitle_file):
+    with patch.object(YtDlpTranscriptBackend, "_find_subtitle_file", return_value=mock_subtitle_file):
         with patch("src.load.video_loader.clean_srt", return_value="Test subtitle"):
             loader = VideoDataLoader(build_settings())
             loader._load("https://youtu.be/test", "test")
@@ -306,30 +477,12 @@ def test_load_no_subtitles_found(mock_youtube_dl_class: MagicMock) -> None:
         None,
     ]
 
-    with patch.object(VideoDataLoader, "_find_subtitle_file", return_value=None):
+    with patch.object(YtDlpTranscriptBackend, "_find_subtitle_file", return_value=None):
         loader = VideoDataLoader(build_settings())
-        with pytest.raises(FileNotFoundError, match="no subtitles found"):
+        with pytest.raises(NoSubtitlesError, match="no subtitles found"):
             loader._load("https://youtu.be/test", "test")
 
 
-def test_is_safe_option_value() -> None:
-    assert _is_safe_option_value("safe_value") is True
-    assert _is_safe_option_value("--option") is True
-    assert _is_safe_option_value("https://example.com") is True
-
-    # Path traversal
-    assert _is_safe_option_value("../unsafe") is False
-    assert _is_safe_option_value("/etc/passwd") is False
-
-    # Shell injection
-    assert _is_safe_option_value("val; rm -rf /") is False
-    assert _is_safe_option_value("val|ls") is False
-    assert _is_safe_option_value("val&ls") is False
-    assert _is_safe_option_value("$(rm -rf /)") is False
-    assert _is_safe_option_value("`rm -rf /`") is False
-    assert _is_safe_option_value("val>out.txt") is False
-
-
 def test_ydl_opts_filters_unsafe_values() -> None:
     # Include an unsafe option value
     unsafe_settings = build_settings(yt_dlp_additional_options=("--format", "mp4", "--outtmpl", "/etc/passwd"))
@@ -339,3 +492,176 @@ def test_ydl_opts_filters_unsafe_values() -> None:
     # The safe format should be included, but the unsafe outtmpl should be omitted
     assert opts.get("format") == "mp4"
     assert "outtmpl" not in opts
+
+
+@patch("yt_dlp.YoutubeDL")
+def test_load_does_not_retry_permanent_errors(mock_youtube_dl_class: MagicMock) -> None:
+    mock_ydl = MagicMock()
+    mock_ydl.__enter__ = MagicMock(return_value=mock_ydl)
+    mock_ydl.__exit__ = MagicMock(return_value=False)
+    mock_youtube_dl_class.return_value = mock_ydl
+    mock_ydl.extract_info.side_effect = Exception("ERROR: [youtube] test: Video unavailable")
+
+    loader = VideoDataLoader(build_settings())
+    with pytest.raises(VideoUnavailableError, match="Failed to load video info: .*Video unavailable"):
+        loader._load("https://youtu.be/test", "test")
+
+    mock_ydl.extract_info.assert_called_once()
+
+
+def test_video_transcript_from_dict_restores_chapters() -> None:
+    transcript = VideoTranscript(
+        id="id",
+        language="en",
+        uploader="uploader",
+        title="title",
+        thumbnail="",
+        transcript="text",
+        duration=120,
+        chapters=(Chapter("Intro", 0.0, 60.0, "intro"), Chapter("Outro", 60.0, None, "outro")),
+    )
+
+    assert VideoTranscript.from_dict(json.loads(json.dumps(asdict(transcript)))) == transcript
+
+
+def test_video_transcript_from_dict_without_chapters() -> None:
+    data = {"id": "id", "language": "en", "uploader": "", "title": "", "thumbnail": "", "transcript": "text", "duration": None}
+
+    assert VideoTranscript.from_dict(data).chapters == ()
+
+
+@patch("yt_dlp.YoutubeDL")
+def test_extract_info_reads_chapters(mock_youtube_dl_class: MagicMock) -> None:
+    mock_ydl = MagicMock()
+    mock_ydl.__enter__ = MagicMock(return_value=mock_ydl)
+    mock_ydl.__exit__ = MagicMock(return_value=False)
+    mock_youtube_dl_class.return_value = mock_ydl
+    mock_ydl.extract_info.return_value = {"id": "id", "chapters": [{"title": "Intro", "start_time": 0.0, "end_time": 60.0}]}
+
+    info = VideoDataLoader(build_settings())._extract_info("https://youtu.be/test")
+
+    assert info.chapters == (Chapter("Intro", 0.0, 60.0),)
+
+
+class CannedSrtBackend(TranscriptBackend):
+    def __init__(self, settings: Settings) -> None:
+        self.calls: list[tuple[str, str, str, bool]] = []
+
+    def fetch(self, url: str, video_id: str, language: str, has_manual_subtitles: bool, cancelled: threading.Event) -> RawSubtitles | None:
+        self.calls.append((url, video_id, language, has_manual_subtitles))
+        return RawSubtitles("1\n00:00:00,000 --> 00:00:01,000\nHello from the fake backend", "srt")
+
+
+@pytest.mark.parametrize(
+    ("subtitles", "has_manual_subtitles"),
+    [({"en": []}, True), ({"en-US": []}, True), ({"pt-BR": []}, False), ({}, False)],
+)
+@patch("yt_dlp.YoutubeDL")
+def test_load_uses_configured_transcript_backend(
+    mock_youtube_dl_class: MagicMock, subtitles: dict[str, list[dict[str, str]]], has_manual_subtitles: bool
+) -> None:
+    mock_ydl = MagicMock()
+    mock_ydl.__enter__ = MagicMock(return_value=mock_ydl)
+    mock_ydl.__exit__ = MagicMock(return_value=False)
+    mock_youtube_dl_class.return_value = mock_ydl
+    mock_ydl.extract_info.return_value = {"id": "test_id", "language": "en", "uploader": "", "title": "", "thumbnail": "", "subtitles": subtitles}
+
+    with patch.dict("src.load.transcript_backend_factory._factories"):
+        register_transcript_backend("canned", CannedSrtBackend)
+        loader = VideoDataLoader(build_settings(feature_flags=FeatureFlags(), transcript_backend="canned"))
+        transcript = loader._load("https://youtu.be/test", "test")
+
+    assert transcript.transcript == "Hello from the fake backend"
+    assert isinstance(loader.transcript_backend, CannedSrtBackend)
+    assert loader.transcript_backend.calls == [("https://youtu.be/test", "test", "en", has_manual_subtitles)]
+    # Only video info is extracted with yt-dlp
+    mock_ydl.extract_info.assert_called_once_with("https://youtu.be/test", download=False)
+
+
+WORD_SEGMENTS_JSON3 = json.dumps({"events": [{"tStartMs": 0, "dDurationMs": 800, "segs": [{"utf8": "Hello"}, {"utf8": " world", "tOffsetMs": 400}]}]})
+
+
+@pytest.mark.parametrize(
+    ("subtitles", "word_timings", "expected_words"),
+    [
+        (RawSubtitles(WORD_SEGMENTS_JSON3, "json3"), True, 2),
+        (RawSubtitles(WORD_SEGMENTS_JSON3, "json3"), False, 0),
+        (RawSubtitles("1\n00:00:00,000 --> 00:00:00,800\nHello world", "srt"), True, 0),
+    ],
+)
+def test_load_keeps_word_timings_of_json3_subtitles(subtitles: RawSubtitles, word_timings: bool, expected_words: int) -> None:
+    loader = VideoDataLoader(build_settings(feature_flags=FeatureFlags(word_timings=word_timings)))
+    loader.transcript_backend = MagicMock(spec=TranscriptBackend)
+    loader.transcript_backend.fetch.return_value = subtitles
+    info = VideoInfo(id="test", language="en", uploader="", title="", thumbnail="", subtitles={})
+
+    with patch.object(VideoDataLoader, "_extract_info", return_value=info):
+        transcript = loader._load("https://youtu.be/test", "test")
+
+    assert transcript.transcript == "Hello world"
+    assert len(transcript.words) == expected_words
+
+
+def test_video_transcript_from_dict_restores_words() -> None:
+    transcript = VideoTranscript(
+        id="test",
+        language="en",
+        uploader="",
+        title="",
+        thumbnail="",
+        transcript="Hello world",
+        words=(WordTiming("Hello", 0, 400), WordTiming("world", 400, 400)),
+    )
+
+    assert VideoTranscript.from_dict(json.loads(json.dumps(asdict(transcript)))) == transcript
+
+
+class BlockingBackend(TranscriptBackend):
+    """Blocks like a slow download until the fetch is cancelled."""
+
+    def __init__(self, settings: Settings) -> None:
+        self.started = threading.Event()
+        self.finished = threading.Event()
+        self.saw_cancel = False
+
+    def fetch(self, url: str, video_id: str, language: str, has_manual_subtitles: bool, cancelled: threading.Event) -> RawSubtitles | None:
+        self.started.set()
+        self.saw_cancel = cancelled.wait(5)
+        self.finished.set()
+        raise FetchCancelledError("cancelled")
+
+
+@pytest.mark.asyncio
+async def test_load_cancellation_stops_transcript_backend() -> None:
+    with patch.dict("src.load.transcript_backend_factory._factories"):
+        register_transcript_backend("blocking", BlockingBackend)
+        loader = VideoDataLoader(build_settings(feature_flags=FeatureFlags(), transcript_backend="blocking"))
+    loader.cache_provider = AsyncMock()
+    loader.cache_provider.get_dict.return_value = None
+    backend = loader.transcript_backend
+    assert isinstance(backend, BlockingBackend)
+    info = VideoInfo(id="test", language="en", uploader="", title="", thumbnail="", subtitles={})
+
+    with patch.object(VideoDataLoader, "_extract_info", return_value=info):
+        task = asyncio.create_task(loader.load("https://youtu.be/dQw4w9WgXcQ"))
+        assert await asyncio.to_thread(backend.started.wait, 5)
+        task.cancel()
+        with pytest.raises(asyncio.CancelledError):
+            await task
+
+    assert await asyncio.to_thread(backend.finished.wait, 5)
+    assert backend.saw_cancel
+    loader.cache_provider.put_dict.assert_not_called()
+
+
+def test_load_skips_fetch_once_cancelled() -> None:
+    loader = VideoDataLoader(build_settings(feature_flags=FeatureFlags()))
+    loader.transcript_backend = MagicMock(spec=TranscriptBackend)
+    info = VideoInfo(id="test", language="en", uploader="", title="", thumbnail="", subtitles={})
+    cancelled = threading.Event()
+    cancelled.set()
+
+    with patch.object(VideoDataLoader, "_extract_info", return_value=info), pytest.raises(FetchCancelledError):
+        loader._load("https://youtu.be/test", "test", cancelled)
+
+    loader.transcript_backend.fetch.assert_not_called()
@@ -0,0 +1,82 @@
+from unittest.mock import AsyncMock, MagicMock, patch
+
+import pytest
+from src.config import Settings
+from src.load.errors import InvalidVideoUrlError
+from src.load.video_loader import VideoDataLoader
+from src.load.video_loader_factory import ProviderVideoLoader, register_video_loader
+from src.load.video_provider import VKVIDEO, YOUTUBE_SHORT, RegexProvider
+
+
+def build_settings() -> Settings:
+    settings = MagicMock(spec=Settings)
+    settings.yt_dlp_additional_options = ()
+    settings.yt_dlp_proxies = ()
+    settings.valkey_url = None
+    settings.cache_compression_method = "gzip"
+    settings.transcript_backend = "yt-dlp"
+    return settings
+
+
+@pytest.mark.parametrize(
+    "url",
+    [
+        "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+        "https://youtu.be/dQw4w9WgXcQ",
+        "https://www.youtube.com/shorts/dQw4w9WgXcQ",
+        "https://vkvideo.ru/video-123_456",
+    ],
+)
+def test_provider_video_loader_for_url_of_each_provider(url: str) -> None:
+    assert isinstance(ProviderVideoLoader(build_settings()).for_url(url), VideoDataLoader)
+
+
+def test_provider_video_loader_reuses_loader_of_provider() -> None:
+    loader = ProviderVideoLoader(build_settings())
+
+    youtube_loader = loader.for_url("https://www.youtube.com/watch?v=dQw4w9WgXcQ")
+
+    assert loader.for_url("https://youtu.be/abcdefghijk") is youtube_loader
+    assert loader.for_url("https://vkvideo.ru/video-123_456") is not youtube_loader
+
+
+@pytest.mark.parametrize(
+    ("provider", "url"),
+    [(YOUTUBE_SHORT, "https://www.youtube.com/shorts/dQw4w9WgXcQ"), (VKVIDEO, "https://vkvideo.ru/video-1_2")],
+)
+def test_register_video_loader(provider: RegexProvider, url: str) -> None:
+    registered = MagicMock(spec=VideoDataLoader)
+    factory = MagicMock(return_value=registered)
+    settings = build_settings()
+
+    with patch.dict("src.load.video_loader_factory._factories"):
+        register_video_loader(provider, factory)
+        loader = ProviderVideoLoader(settings)
+
+        assert loader.for_url(url) is registered
+        assert loader.for_url(url) is registered
+        # Other providers keep the default loader
+        assert isinstance(loader.for_url("https://youtu.be/dQw4w9WgXcQ"), VideoDataLoader)
+
+    factory.assert_called_once_with(settings)
+
+
+@pytest.mark.parametrize("url", ["https://example.com/video", "not a url"])
+def test_provider_video_loader_unsupported_url(url: str) -> None:
+    with pytest.raises(InvalidVideoUrlError, match="no valid URL found"):
+        ProviderVideoLoader(build_settings()).for_url(url)
+
+
+@pytest.mark.asyncio
+@pytest.mark.parametrize("refresh", [False, True])
+async def test_provider_video_loader_loads_with_provider_loader(refresh: bool) -> None:
+    registered = MagicMock(spec=VideoDataLoader)
+    registered.load = AsyncMock(return_value="transcript")
+    url = "https://vkvideo.ru/video-1_2"
+
+    with patch.dict("src.load.video_loader_factory._factories"):
+        register_video_loader(VKVIDEO, lambda settings: registered)
+
+        assert await ProviderVideoLoader(build_settings()).load(url, refresh=refresh) == "transcript"
+
+    registered.load.assert_called_once_with(url, refresh=refresh)
@@ -1,82 +1,23 @@
 import pytest
+from src.load.errors import InvalidVideoUrlError
 from src.load.video_provider import (
     PROVIDERS,
     VKVIDEO,
     YOUTUBE,
     YOUTUBE_SHORT,
+    ParsedURL,
     build_video_source,
-    extract_urls,
+    find_provider,
+    parse_video_url,
 )
 
 
-def test_extract_urls_youtube() -> None:
-    text = "text https://youtu.be/validID1234 and https://www.youtube.com/watch?v=anotherID12"
-    assert extract_urls(text) == [
-        "https://youtu.be/validID1234",
-        "https://www.youtube.com/watch?v=anotherID12",
-    ]
-
-
 def test_build_video_source_canonicalizes_short_link() -> None:
     canonical, video_id = build_video_source("youtu.be/abcdefghijk")
     assert video_id == "abcdefghijk"
     assert canonical == "https://www.youtube.com/watch?v=abcdefghijk"
 
 
-def test_extract_urls_youtube_full_urls() -> None:
-    text = "Check out https://www.youtube.com/watch?v=dQw4w9WgXcQ and https://youtube.com/watch?v=abc123def45"
-    assert extract_urls(text) == [
-        "https://www.youtube.com/watch?v=dQw4w9WgXcQ",
-        "https://youtube.com/watch?v=abc123def45",
-    ]
-
-
-def test_extract_urls_youtube_with_params() -> None:
-    text = "Video: https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=1s&ab_channel=Channel"
-    result = extract_urls(text)
-    # The extract_urls function extracts the full URLs that match the pattern
-    assert len(result) == 1
-    # The result should be the full URL with parameters
-    assert result[0] == "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
-
-
-def test_extract_urls_youtube_shorts() -> None:
-    text = "Short: https://www.youtube.com/shorts/abcdefghijk and https://youtube.com/shorts/xyz123"
-    extracted = extract_urls(text)
-    # Only the first one should match since the pattern doesn't include non-www youtube.com/shorts
-    assert len(extracted) == 1
-    assert extracted[0] == "https://www.youtube.com/shorts/abcdefghijk"
-
-
-def test_extract_urls_vkvideo() -> None:
-    text = "VK: https://vkvideo.ru/video-123456_789012 and http://www.vkvideo.ru/video-987654_321098"
-    assert extract_urls(text) == [
-        "https://vkvideo.ru/video-123456_789012",
-        "http://www.vkvideo.ru/video-987654_321098",
-    ]
-
-
-def test_extract_urls_mixed_providers() -> None:
-    # The extract_urls function returns URLs from the first provider that matches in the text
-    # Since PROVIDERS = (YOUTUBE, YOUTUBE_SHORT, VKVIDEO), it will match YouTube URLs first
-    # Using proper 11-character IDs
-    text = "YouTube: https://youtu.be/abcdefghijk, Shorts: https://www.youtube.com/shorts/klmnopqrstu, VK: https://vkvideo.ru/video-123_456"
-    extracted = extract_urls(text)
-    # Should find YouTube URLs first since YOUTUBE is the first provider in PROVIDERS
-    assert len(extracted) == 1
-    assert extracted[0] == "https://youtu.be/abcdefghijk"
-
-
-def test_extract_urls_no_urls() -> None:
-    text = "This text contains no video URLs"
-    assert extract_urls(text) == []
-
-
-def test_extract_urls_invalid_urls() -> None:
-    text = "Invalid: https://youtube.com/invalid, https://youtu.be/, https://vkvideo.ru/"
-    assert extract_urls(text) == []
-
-
 def test_build_video_source_youtube_full_url() -> None:
     canonical, video_id = build_video_source("https://www.youtube.com/watch?v=dQw4w9WgXcQ")
     assert video_id == "dQw4w9WgXcQ"
@@ -102,7 +43,7 @@ def test_build_video_source_vkvideo() -> None:
 
 
 def test_build_video_source_invalid_url() -> None:
-    with pytest.raises(ValueError, match="no valid URL found"):
+    with pytest.raises(InvalidVideoUrlError, match="no valid URL found"):
         build_video_source("https://invalid.com/video")
 
 
@@ -112,6 +53,27 @@ def test_youtube_provider_is_valid_url() -> None:
     assert not YOUTUBE.is_valid_url("https://www.twitch.tv/user")
 
 
+@pytest.mark.parametrize(
+    ("text", "expected_url"),
+    [
+        ("Live now: https://www.youtube.com/live/dQw4w9WgXcQ?si=abc123 join!", "https://www.youtube.com/live/dQw4w9WgXcQ"),
+        ("https://youtube.com/live/dQw4w9WgXcQ?feature=share", "https://youtube.com/live/dQw4w9WgXcQ"),
+        ("from my phone https://m.youtube.com/watch?v=dQw4w9WgXcQ&feature=youtu.be", "https://m.youtube.com/watch?v=dQw4w9WgXcQ"),
+        ("m.youtube.com/watch?v=dQw4w9WgXcQ", "m.youtube.com/watch?v=dQw4w9WgXcQ"),
+        ("Song: https://music.youtube.com/watch?v=dQw4w9WgXcQ&list=RDAMVMdQw4w9WgXcQ", "https://music.youtube.com/watch?v=dQw4w9WgXcQ"),
+    ],
+)
+def test_youtube_provider_mobile_live_and_music_urls(text: str, expected_url: str) -> None:
+    assert YOUTUBE.extract_urls(text) == [expected_url]
+    assert build_video_source(text) == ("https://www.youtube.com/watch?v=dQw4w9WgXcQ", "dQw4w9WgXcQ")
+
+
+def test_youtube_provider_rejects_embed_and_short_ids() -> None:
+    assert not YOUTUBE.is_valid_url("https://www.youtube.com/embed/dQw4w9WgXcQ")
+    assert not YOUTUBE.is_valid_url("https://www.youtube.com/live/dQw4w9")
+    assert not YOUTUBE.is_valid_url("https://m.youtube.com/watch?v=short")
+
+
 def test_youtube_short_provider_is_valid_url() -> None:
     assert YOUTUBE_SHORT.is_valid_url("https://www.youtube.com/shorts/abcdefghijk")
     # The pattern doesn't match non-www youtube.com/shorts URLs
@@ -144,3 +106,61 @@ def test_all_providers_defined() -> None:
     assert YOUTUBE in PROVIDERS
     assert YOUTUBE_SHORT in PROVIDERS
     assert VKVIDEO in PROVIDERS
+
+
+@pytest.mark.parametrize(
+    ("url", "expected_name"),
+    [
+        ("https://www.youtube.com/watch?v=dQw4w9WgXcQ", "YouTube"),
+        ("https://youtube.com/shorts/dQw4w9WgXcQ", "YouTube Shorts"),
+        ("https://vkvideo.ru/video-123456_789012", "VK Video"),
+    ],
+)
+def test_find_provider_reports_name(url: str, expected_name: str) -> None:
+    provider = find_provider(url)
+
+    assert provider is not None
+    assert provider.name == expected_name
+
+
+def test_find_provider_unsupported_url() -> None:
+    assert find_provider("https://example.com/video") is None
+
+
+@pytest.mark.parametrize(
+    ("url", "expected"),
+    [
+        ("https://www.youtube.com/watch?v=dQw4w9WgXcQ", ParsedURL("dQw4w9WgXcQ", None)),
+        ("https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=30s", ParsedURL("dQw4w9WgXcQ", 30)),
+        ("https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=90", ParsedURL("dQw4w9WgXcQ", 90)),
+        ("https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=1h2m3s", ParsedURL("dQw4w9WgXcQ", 3723)),
+        ("https://www.youtube.com/watch?v=dQw4w9WgXcQ&start=45", ParsedURL("dQw4w9WgXcQ", 45)),
+        ("https://www.youtube.com/watch?v=dQw4w9WgXcQ#t=1m30s", ParsedURL("dQw4w9WgXcQ", 90)),
+        ("https://youtu.be/dQw4w9WgXcQ?t=42", ParsedURL("dQw4w9WgXcQ", 42)),
+        ("youtu.be/dQw4w9WgXcQ?si=abc&t=5", ParsedURL("dQw4w9WgXcQ", 5)),
+        ("https://www.youtube.com/watch?v=dQw4w9WgXcQ&list=PLabcdefghijklmnop&index=3", ParsedURL("dQw4w9WgXcQ", None)),
+        ("https://www.youtube.com/watch?list=PLabcdefghijklmnop&v=dQw4w9WgXcQ&t=12s", ParsedURL("dQw4w9WgXcQ", 12)),
+        ("https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=soon", ParsedURL("dQw4w9WgXcQ", None)),
+        ("https://www.youtube.com/shorts/abcdefghijk?feature=share", ParsedURL("abcdefghijk", None)),
+        ("https://vkvideo.ru/video-123456_789012?t=1m", ParsedURL("video-123456_789012", 60)),
+    ],
+)
+def test_parse_video_url(url: str, expected: ParsedURL) -> None:
+    assert parse_video_url(url) == expected
+
+
+def test_parse_video_url_ignores_following_text() -> None:
+    parsed = YOUTUBE.parse("watch https://youtu.be/dQw4w9WgXcQ?t=10 from https://example.com/?t=99")
+
+    assert parsed == ParsedURL("dQw4w9WgXcQ", 10)
+
+
+def test_parse_video_url_keeps_get_id() -> None:
+    url = "https://www.youtube.com/watch?v=dQw4w9WgXcQ&list=PLabcdefghijklmnop&t=30s"
+
+    assert YOUTUBE.get_id(url) == parse_video_url(url).id
+
+
+def test_parse_video_url_invalid_url() -> None:
+    with pytest.raises(ValueError, match="no valid URL found"):
+        parse_video_url("https://example.com/watch?v=dQw4w9WgXcQ")
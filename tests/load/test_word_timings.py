@@ -0,0 +1,61 @@
+import json
+
+import pytest
+from src.load.word_timings import WordTiming, parse_json3_words
+
+# Trimmed from the json3 auto-generated captions of a YouTube video: word segments with
+# offsets, newline-only "aAppend" events between captions and an HTML entity.
+CAPTURED_JSON3 = json.dumps(
+    {
+        "wireMagic": "pb3",
+        "pens": [{}],
+        "wsWinStyles": [{}, {"mhModeHint": 2, "juJustifCode": 0, "sdScrollDir": 3}],
+        "wpWinPositions": [{}, {"apPoint": 6, "ahHorPos": 20, "avVerPos": 100, "rcRows": 2, "ccCols": 40}],
+        "events": [
+            {"tStartMs": 0, "dDurationMs": 5680, "id": 1, "wpWinPosId": 1, "wsWinStyleId": 1},
+            {
+                "tStartMs": 160,
+                "dDurationMs": 2960,
+                "wWinId": 1,
+                "segs": [{"utf8": "we're", "acAsrConf": 0}, {"utf8": " no", "tOffsetMs": 320}, {"utf8": " strangers", "tOffsetMs": 480}],
+            },
+            {"tStartMs": 3110, "dDurationMs": 10, "wWinId": 1, "aAppend": 1, "segs": [{"utf8": "\n"}]},
+            {
+                "tStartMs": 3120,
+                "dDurationMs": 2560,
+                "wWinId": 1,
+                "segs": [{"utf8": "to"}, {"utf8": " love", "tOffsetMs": 240}, {"utf8": " &amp;", "tOffsetMs": 800}],
+            },
+        ],
+    }
+)
+
+
+def test_parse_json3_words_captured_captions() -> None:
+    expected_words = (
+        WordTiming("we're", 160, 320),
+        WordTiming("no", 480, 160),
+        WordTiming("strangers", 640, 2480),
+        WordTiming("to", 3120, 240),
+        WordTiming("love", 3360, 560),
+        WordTiming("&", 3920, 1760),
+    )
+
+    assert parse_json3_words(CAPTURED_JSON3) == expected_words
+
+
+def test_parse_json3_words_manual_captions_are_whole_segments() -> None:
+    text = json.dumps({"events": [{"tStartMs": 1000, "dDurationMs": 2000, "segs": [{"utf8": "Never gonna give you up"}]}]})
+
+    assert parse_json3_words(text) == (WordTiming("Never gonna give you up", 1000, 2000),)
+
+
+def test_parse_json3_words_without_duration() -> None:
+    text = json.dumps({"events": [{"tStartMs": 1000, "segs": [{"utf8": "hello"}]}]})
+
+    assert parse_json3_words(text) == (WordTiming("hello", 1000, 0),)
+
+
+@pytest.mark.parametrize("text", ["not json", "[]", "{}"])
+def test_parse_json3_words_invalid_content(text: str) -> None:
+    assert parse_json3_words(text) == ()
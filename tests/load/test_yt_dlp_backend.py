@@ -0,0 +1,199 @@
+import threading
+from pathlib import Path
+from unittest.mock import MagicMock, patch
+
+import pytest
+from src.config import FeatureFlags, Settings
+from src.load.transcript_backend import FetchCancelledError, RawSubtitles
+from src.load.yt_dlp_backend import YtDlpTranscriptBackend, _SubtitleDownload
+from src.load.yt_dlp_logger import YtDlpCaptureLogger
+
+
+def build_settings(**overrides: object) -> Settings:
+    settings = MagicMock(spec=Settings)
+    settings.yt_dlp_additional_options = ()
+    settings.yt_dlp_proxies = ()
+    settings.yt_dlp_allow_any_options = False
+    settings.yt_dlp_max_attempts = 3
+    settings.yt_dlp_retry_delay_seconds = 0
+    settings.yt_dlp_cookies_file = None
+    settings.subtitle_format = "srt"
+    settings.prefer_manual_subtitles = False
+    settings.feature_flags = FeatureFlags()
+    for key, value in overrides.items():
+        setattr(settings, key, value)
+    return settings
+
+
+def build_download(cancelled: threading.Event | None = None) -> _SubtitleDownload:
+    return _SubtitleDownload("https://youtu.be/test", "test", "en", YtDlpCaptureLogger(), cancelled or threading.Event())
+
+
+def test_subtitle_template_path() -> None:
+    with patch("tempfile.gettempdir") as mock_temp:
+        mock_temp.return_value = "/tmp"
+
+        backend = YtDlpTranscriptBackend(build_settings())
+        expected_path = "/tmp/subtitles_test.%(ext)s"
+
+        assert backend._get_subtitle_template_path("test") == expected_path
+
+
+def test_subtitle_prefix() -> None:
+    with patch("tempfile.gettempdir") as mock_temp:
+        mock_temp.return_value = "/tmp"
+
+        backend = YtDlpTranscriptBackend(build_settings())
+        expected_prefix = Path("/tmp/subtitles_test")
+
+        assert backend._get_subtitle_prefix("test") == expected_prefix
+
+
+@pytest.mark.parametrize("ext", ["en.srt", "en_auto.vtt", "en.json3"])
+def test_subtitle_template_path_matches_prefix(tmp_path: Path, ext: str) -> None:
+    backend = YtDlpTranscriptBackend(build_settings())
+
+    with patch("tempfile.gettempdir", return_value=str(tmp_path)):
+        # yt-dlp substitutes the language and format for %(ext)s when it writes the subtitles.
+        written = Path(backend._get_subtitle_template_path("test") % {"ext": ext})
+        written.write_text("subtitles", encoding="utf-8")
+
+        assert written == backend._get_subtitle_prefix("test").with_suffix(f".{ext}")
+        assert backend._find_subtitle_file("test", "en") == written
+        backend._cleanup_subtitle_files("test")
+
+    assert not written.exists()
+
+
+def test_find_subtitle_file_exact_match() -> None:
+    backend = YtDlpTranscriptBackend(build_settings())
+
+    with patch("tempfile.gettempdir", return_value="/tmp"):
+        with patch.object(Path, "exists", return_value=True):
+            result = backend._find_subtitle_file("test", "en")
+            expected_path = Path("/tmp") / "subtitles_test.en.srt"
+            assert result == expected_path
+
+
+def test_find_subtitle_file_vtt_fallback() -> None:
+    """When .srt does not exist, the backend should fall back to .vtt."""
+    with patch("tempfile.gettempdir", return_value="/tmp"):
+        backend = YtDlpTranscriptBackend(build_settings())
+
+        def exists_only_vtt(self: Path) -> bool:
+            return str(self).endswith(".en.vtt")
+
+        with patch.object(Path, "exists", exists_only_vtt):
+            result = backend._find_subtitle_file("test", "en")
+            assert result is not None
+            assert str(result).endswith(".en.vtt")
+
+
+@pytest.mark.parametrize(("subtitle_format", "expected_name"), [("srt", "subtitles_test.en.srt"), ("vtt", "subtitles_test.en.vtt")])
+def test_find_subtitle_file_prefers_configured_format(tmp_path: Path, subtitle_format: str, expected_name: str) -> None:
+    for name in ("subtitles_test.en.srt", "subtitles_test.en.vtt"):
+        (tmp_path / name).write_text("subtitles", encoding="utf-8")
+
+    backend = YtDlpTranscriptBackend(build_settings(subtitle_format=subtitle_format))
+    with patch("tempfile.gettempdir", return_value=str(tmp_path)):
+        result = backend._find_subtitle_file("test", "en")
+
+    assert result == tmp_path / expected_name
+
+
+@pytest.mark.parametrize(("subtitle_format", "expected_preference"), [("srt", "srt/vtt/json3/best"), ("vtt", "vtt/srt/json3/best")])
+def test_download_subtitles_requests_configured_format_first(subtitle_format: str, expected_preference: str) -> None:
+    backend = YtDlpTranscriptBackend(build_settings(subtitle_format=subtitle_format))
+
+    with patch("yt_dlp.YoutubeDL") as mock_youtube_dl_class:
+        backend._download_subtitles(build_download())
+
+    assert mock_youtube_dl_class.call_args.args[0]["subtitlesformat"] == expected_preference
+
+
+def test_download_subtitles_requests_regional_tracks_of_language() -> None:
+    backend = YtDlpTranscriptBackend(build_settings())
+
+    with patch("yt_dlp.YoutubeDL") as mock_youtube_dl_class:
+        backend._download_subtitles(build_download())
+
+    assert mock_youtube_dl_class.call_args.args[0]["subtitleslangs"] == ["en", "en-.*", "en_auto", "-live_chat"]
+
+
+def test_download_subtitles_requests_json3_first_for_word_timings() -> None:
+    backend = YtDlpTranscriptBackend(build_settings(subtitle_format="vtt", feature_flags=FeatureFlags(word_timings=True)))
+
+    with patch("yt_dlp.YoutubeDL") as mock_youtube_dl_class:
+        backend._download_subtitles(build_download())
+
+    assert mock_youtube_dl_class.call_args.args[0]["subtitlesformat"] == "json3/srt/vtt/best"
+
+
+def test_download_subtitles_rotates_proxies() -> None:
+    backend = YtDlpTranscriptBackend(build_settings(yt_dlp_proxies=("http://proxy-a:8080", "socks5://proxy-b:1080")))
+
+    with patch("yt_dlp.YoutubeDL") as mock_youtube_dl_class:
+        for _ in range(3):
+            backend._download_subtitles(build_download())
+
+    proxies = [call.args[0]["proxy"] for call in mock_youtube_dl_class.call_args_list]
+    assert proxies == ["http://proxy-a:8080", "socks5://proxy-b:1080", "http://proxy-a:8080"]
+
+
+@pytest.mark.parametrize(("file_name", "expected_format"), [("subtitles_test.en.vtt", "vtt"), ("subtitles_test.en_auto.json3", "json3")])
+def test_fetch_reads_and_removes_subtitle_file(tmp_path: Path, file_name: str, expected_format: str) -> None:
+    backend = YtDlpTranscriptBackend(build_settings())
+
+    def download(download: _SubtitleDownload, automatic: bool) -> None:
+        (tmp_path / file_name).write_text("raw subtitles", encoding="utf-8")
+
+    with (
+        patch("tempfile.gettempdir", return_value=str(tmp_path)),
+        patch.object(YtDlpTranscriptBackend, "_download_subtitles", side_effect=download),
+    ):
+        subtitles = backend.fetch("https://youtu.be/test", "test", "en", has_manual_subtitles=False, cancelled=threading.Event())
+
+    assert subtitles == RawSubtitles("raw subtitles", expected_format)
+    assert list(tmp_path.iterdir()) == []
+
+
+def test_fetch_without_subtitles(tmp_path: Path) -> None:
+    backend = YtDlpTranscriptBackend(build_settings())
+
+    with (
+        patch("tempfile.gettempdir", return_value=str(tmp_path)),
+        patch.object(YtDlpTranscriptBackend, "_download_subtitles"),
+    ):
+        assert backend.fetch("https://youtu.be/test", "test", "en", has_manual_subtitles=True, cancelled=threading.Event()) is None
+
+
+def test_fetch_stops_and_removes_partial_file_once_cancelled(tmp_path: Path) -> None:
+    backend = YtDlpTranscriptBackend(build_settings())
+    cancelled = threading.Event()
+
+    def extract_info(url: str, download: bool) -> None:
+        (tmp_path / "subtitles_test.en.srt.part").write_text("partial", encoding="utf-8")
+        cancelled.set()
+        mock_youtube_dl_class.call_args.args[0]["progress_hooks"][0]({"status": "downloading"})
+
+    with (
+        patch("tempfile.gettempdir", return_value=str(tmp_path)),
+        patch("yt_dlp.YoutubeDL") as mock_youtube_dl_class,
+    ):
+        mock_youtube_dl_class.return_value.__enter__.return_value.extract_info.side_effect = extract_info
+        with pytest.raises(FetchCancelledError):
+            backend.fetch("https://youtu.be/test", "test", "en", has_manual_subtitles=True, cancelled=cancelled)
+
+    assert mock_youtube_dl_class.call_count == 1
+    assert list(tmp_path.iterdir()) == []
+
+
+def test_download_subtitles_skipped_once_cancelled() -> None:
+    backend = YtDlpTranscriptBackend(build_settings())
+    cancelled = threading.Event()
+    cancelled.set()
+
+    with patch("yt_dlp.YoutubeDL") as mock_youtube_dl_class, pytest.raises(FetchCancelledError):
+        backend._download_subtitles(build_download(cancelled))
+
+    mock_youtube_dl_class.assert_not_called()
@@ -0,0 +1,33 @@
+from concurrent.futures import ThreadPoolExecutor
+
+from src.load.yt_dlp_proxies import ProxyRotator
+
+
+def test_proxy_rotator_without_proxies() -> None:
+    rotator = ProxyRotator(())
+
+    assert rotator.next() is None
+
+
+def test_proxy_rotator_single_proxy() -> None:
+    rotator = ProxyRotator(("http://proxy:8080",))
+
+    assert [rotator.next() for _ in range(3)] == ["http://proxy:8080"] * 3
+
+
+def test_proxy_rotator_round_robin() -> None:
+    rotator = ProxyRotator(("http://a:8080", "http://b:8080", "http://c:8080"))
+
+    assert [rotator.next() for _ in range(4)] == ["http://a:8080", "http://b:8080", "http://c:8080", "http://a:8080"]
+
+
+def test_proxy_rotator_is_thread_safe() -> None:
+    proxies = ("http://a:8080", "http://b:8080")
+    rotator = ProxyRotator(proxies)
+    calls = 1000
+
+    with ThreadPoolExecutor(max_workers=8) as executor:
+        results = list(executor.map(lambda _: rotator.next(), range(calls)))
+
+    assert results.count("http://a:8080") == calls // len(proxies)
+    assert results.count("http://b:8080") == calls // len(proxies)
@@ -0,0 +1,74 @@
+from unittest.mock import MagicMock, patch
+
+import pytest
+from src.load.errors import VideoPrivateError, VideoUnavailableError
+from src.load.yt_dlp_retry import call_with_retry, is_transient_error
+
+
+@pytest.mark.parametrize(
+    ("error", "expected"),
+    [
+        (TimeoutError("read timed out"), True),
+        (ConnectionResetError("reset by peer"), True),
+        (Exception("ERROR: Unable to download webpage: <urlopen error [Errno -3] Temporary failure in name resolution>"), True),
+        (Exception("ERROR: Unable to download webpage: HTTP Error 503: Service Unavailable"), True),
+        (Exception("ERROR: [youtube] abc: Video unavailable"), False),
+        (Exception("ERROR: [youtube] abc: Private video. Sign in if you've been granted access"), False),
+    ],
+)
+def test_is_transient_error(error: Exception, expected: bool) -> None:
+    assert is_transient_error(error) is expected
+
+
+def test_call_with_retry_succeeds_after_transient_failures() -> None:
+    action = MagicMock(side_effect=[TimeoutError("timed out"), ConnectionError("reset"), "info"])
+
+    with patch("src.load.yt_dlp_retry.time.sleep") as mock_sleep:
+        assert call_with_retry(action, "load video info", 3, 0.5, dict) == "info"
+
+    expected_calls = 3
+    assert action.call_count == expected_calls
+    assert [call.args[0] for call in mock_sleep.call_args_list] == [0.5, 1.0]
+
+
+def test_call_with_retry_raises_when_exhausted() -> None:
+    action = MagicMock(side_effect=TimeoutError("timed out"))
+
+    with patch("src.load.yt_dlp_retry.time.sleep"), pytest.raises(RuntimeError, match="Failed to load video info after 2 attempts"):
+        call_with_retry(action, "load video info", 2, 1.0, dict)
+
+    expected_calls = 2
+    assert action.call_count == expected_calls
+
+
+def test_call_with_retry_fails_fast_on_permanent_error() -> None:
+    action = MagicMock(side_effect=Exception("Video unavailable"))
+
+    with (
+        patch("src.load.yt_dlp_retry.time.sleep") as mock_sleep,
+        pytest.raises(RuntimeError, match="Failed to download subtitles: Video unavailable"),
+    ):
+        call_with_retry(action, "download subtitles", 3, 1.0, dict)
+
+    action.assert_called_once()
+    mock_sleep.assert_not_called()
+
+
+@pytest.mark.parametrize(
+    ("error", "expected_type"),
+    [
+        (Exception("ERROR: [youtube] abc: Private video. Sign in if you've been granted access to this video"), VideoPrivateError),
+        (Exception("ERROR: [youtube] abc: Video unavailable. This video has been removed by the uploader"), VideoUnavailableError),
+        (Exception("ERROR: [youtube] abc: The uploader has not made this video available in your country"), VideoUnavailableError),
+        (Exception("ERROR: [youtube] abc: Join this channel to get access to members-only content"), VideoUnavailableError),
+        (Exception("ERROR: Unsupported URL: https://example.com"), RuntimeError),
+    ],
+)
+def test_call_with_retry_raises_typed_permanent_errors(error: Exception, expected_type: type[Exception]) -> None:
+    action = MagicMock(side_effect=error)
+
+    with pytest.raises(RuntimeError, match="Failed to load video info") as exc_info:
+        call_with_retry(action, "load video info", 3, 1.0, dict)
+
+    assert type(exc_info.value) is expected_type
+    action.assert_called_once()
@@ -0,0 +1,33 @@
+import pytest
+from src.cache import InMemoryCacheProvider
+from src.language_preferences import UserLanguagePreferences
+
+
+@pytest.mark.asyncio
+async def test_language_preferences_default_is_none() -> None:
+    preferences = UserLanguagePreferences(provider=InMemoryCacheProvider())
+
+    assert await preferences.get(123) is None
+
+
+@pytest.mark.asyncio
+async def test_language_preferences_set_and_get() -> None:
+    preferences = UserLanguagePreferences(provider=InMemoryCacheProvider())
+
+    await preferences.set(123, "de")
+
+    assert await preferences.get(123) == "de"
+    assert await preferences.get(456) is None
+
+
+@pytest.mark.asyncio
+async def test_chat_language_set_get_and_clear() -> None:
+    preferences = UserLanguagePreferences(provider=InMemoryCacheProvider())
+
+    await preferences.set_chat(-100123, "ja")
+    assert await preferences.get_chat(-100123) == "ja"
+    # Chat and user preferences do not collide even if the IDs do.
+    assert await preferences.get(-100123) is None
+
+    await preferences.set_chat(-100123, None)
+    assert await preferences.get_chat(-100123) is None
@@ -1,6 +1,6 @@
 from unittest.mock import patch
 
-from src.localization import DEFAULT_LOCALE, _setup_i18n, normalize_locale, translate
+from src.localization import DEFAULT_LOCALE, _setup_i18n, normalize_locale, supported_locales, translate
 
 
 def testnormalize_locale_none() -> None:
@@ -111,3 +111,11 @@ def test_translate_with_none_locale() -> None:
         assert result == "Default translation"
         # Should use default locale when none provided
         mock_i18n.t.assert_called_once_with("some.key", locale=DEFAULT_LOCALE)
+
+
+def test_supported_locales_match_locale_files() -> None:
+    locales = supported_locales()
+
+    assert DEFAULT_LOCALE in locales
+    assert "ru" in locales
+    assert list(locales) == sorted(locales)
@@ -0,0 +1,40 @@
+import logging
+
+from src.log_context import LogContextFilter, add_log_context, bind_log_context
+
+
+def build_record(**extra: object) -> logging.LogRecord:
+    record = logging.LogRecord("test", logging.INFO, __file__, 1, "message", None, None)
+    for key, value in extra.items():
+        setattr(record, key, value)
+    return record
+
+
+def test_filter_attaches_bound_fields() -> None:
+    record = build_record()
+    with bind_log_context(userID=123, correlation_id="123"):
+        add_log_context(videoID="dQw4w9WgXcQ", correlation_id="123:dQw4w9WgXcQ")
+        assert LogContextFilter().filter(record) is True
+
+    assert record.userID == 123
+    assert record.videoID == "dQw4w9WgXcQ"
+    assert record.correlation_id == "123:dQw4w9WgXcQ"
+
+
+def test_filter_keeps_explicit_extra() -> None:
+    record = build_record(userID=456)
+    with bind_log_context(userID=123):
+        LogContextFilter().filter(record)
+
+    assert record.userID == 456
+
+
+def test_context_does_not_leak_outside_bind() -> None:
+    with bind_log_context(userID=123):
+        pass
+    add_log_context(videoID="dQw4w9WgXcQ")
+    record = build_record()
+    LogContextFilter().filter(record)
+
+    assert not hasattr(record, "userID")
+    assert not hasattr(record, "videoID")
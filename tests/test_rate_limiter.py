@@ -48,3 +48,38 @@ async def test_user_rate_limiter_after_cooldown() -> None:
     # Third request after cooldown should not be limited
     is_limited = await limiter.is_limited(123)
     assert is_limited is False
+
+
+class FakeClock:
+    def __init__(self) -> None:
+        self.now = 1000.0
+
+    def __call__(self) -> float:
+        return self.now
+
+    def advance(self, seconds: float) -> None:
+        self.now += seconds
+
+
+@pytest.mark.asyncio
+async def test_user_rate_limiter_limited_within_window() -> None:
+    clock = FakeClock()
+    limiter = UserRateLimiter(provider=InMemoryCacheProvider(clock=clock), cooldown_seconds=30)
+
+    assert await limiter.is_limited(123) is False
+
+    clock.advance(29.9)
+    assert await limiter.is_limited(123) is True
+
+
+@pytest.mark.asyncio
+async def test_user_rate_limiter_allowed_after_window() -> None:
+    clock = FakeClock()
+    limiter = UserRateLimiter(provider=InMemoryCacheProvider(clock=clock), cooldown_seconds=30)
+
+    assert await limiter.is_limited(123) is False
+
+    clock.advance(30)
+    assert await limiter.is_limited(123) is False
+    # The allowed request starts a new window
+    assert await limiter.is_limited(123) is True
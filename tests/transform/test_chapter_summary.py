@@ -0,0 +1,56 @@
+from unittest.mock import AsyncMock, MagicMock
+
+import pytest
+from src.load.chapters import Chapter
+from src.load.video_loader import VideoTranscript
+from src.transform.chapter_summary import MAX_CHAPTERS, summarize_transcript
+from src.transform.summary_style import SummaryStyle
+
+
+def build_transcript(chapters: tuple[Chapter, ...] = ()) -> VideoTranscript:
+    return VideoTranscript(
+        id="id", language="en", uploader="uploader", title="Title", thumbnail="", transcript="Whole transcript", chapters=chapters
+    )
+
+
+def build_summarizer() -> MagicMock:
+    summarizer = MagicMock()
+    summarizer.summarize = AsyncMock(side_effect=lambda text, locale, style, refresh: f"Summary of {text}")
+    return summarizer
+
+
+@pytest.mark.asyncio
+async def test_summarize_transcript_by_chapters() -> None:
+    chapters = (Chapter("Intro", 0.0, 60.0, "intro text"), Chapter("*Setup*", 60.0, None, "setup text"), Chapter("", 3725.0, None, "outro text"))
+    summarizer = build_summarizer()
+
+    summary = await summarize_transcript(summarizer, build_transcript(chapters), "en", SummaryStyle.SHORT, by_chapters=True)
+
+    assert summary == (
+        "**Intro (0:00)**\nSummary of intro text\n\n**\\*Setup\\* (1:00)**\nSummary of setup text\n\n**1:02:05**\nSummary of outro text"
+    )
+    assert [call.args for call in summarizer.summarize.call_args_list] == [
+        ("intro text", "en", SummaryStyle.SHORT),
+        ("setup text", "en", SummaryStyle.SHORT),
+        ("outro text", "en", SummaryStyle.SHORT),
+    ]
+
+
+@pytest.mark.parametrize(
+    ("chapters", "by_chapters"),
+    [
+        ((), True),  # no chapters
+        ((Chapter("Only", 0.0, None, "text"),), True),  # a single chapter is the whole video
+        ((Chapter("A", 0.0, 1.0, "a"), Chapter("B", 1.0, None, "b")), False),  # chapter mode off
+        (tuple(Chapter(str(i), float(i), None, "text") for i in range(MAX_CHAPTERS + 1)), True),  # too many chapters
+        ((Chapter("A", 0.0, 1.0, "a"), Chapter("B", 1.0, None, "")), True),  # only one chapter has text
+    ],
+)
+@pytest.mark.asyncio
+async def test_summarize_transcript_falls_back_to_whole_video(chapters: tuple[Chapter, ...], by_chapters: bool) -> None:
+    summarizer = build_summarizer()
+
+    summary = await summarize_transcript(summarizer, build_transcript(chapters), "en", by_chapters=by_chapters)
+
+    assert summary == "Summary of Whole transcript"
+    summarizer.summarize.assert_called_once_with("Whole transcript", "en", None, refresh=False)
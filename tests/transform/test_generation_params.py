@@ -0,0 +1,27 @@
+import pytest
+from src.transform.generation_params import GenerationParams
+
+
+@pytest.mark.parametrize(
+    ("params", "overrides", "expected"),
+    [
+        (GenerationParams(0.3, 512), None, GenerationParams(0.3, 512)),
+        (GenerationParams(0.3, 512), GenerationParams(), GenerationParams(0.3, 512)),
+        (GenerationParams(0.3, 512), GenerationParams(temperature=0.0), GenerationParams(0.0, 512)),
+        (GenerationParams(), GenerationParams(max_tokens=64), GenerationParams(None, 64)),
+    ],
+)
+def test_generation_params_override(params: GenerationParams, overrides: GenerationParams | None, expected: GenerationParams) -> None:
+    assert params.override(overrides) == expected
+
+
+def test_generation_params_request_kwargs_skip_unset() -> None:
+    assert GenerationParams().request_kwargs() == {}
+    assert GenerationParams(temperature=0.0).request_kwargs() == {"temperature": 0.0}
+    assert GenerationParams(0.7, 300).request_kwargs() == {"temperature": 0.7, "max_tokens": 300}
+
+
+@pytest.mark.parametrize(("temperature", "max_tokens"), [(-0.1, None), (2.5, None), (None, 0)])
+def test_generation_params_validates_ranges(temperature: float | None, max_tokens: int | None) -> None:
+    with pytest.raises(ValueError):
+        GenerationParams(temperature=temperature, max_tokens=max_tokens)
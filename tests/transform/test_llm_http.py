@@ -0,0 +1,21 @@
+from unittest.mock import MagicMock, patch
+
+from src.config import Settings
+from src.transform.llm_http import CONNECT_TIMEOUT_SECONDS, KEEPALIVE_EXPIRY_SECONDS, build_http_client
+
+
+def test_build_http_client_pools_connections() -> None:
+    settings = MagicMock(spec=Settings)
+    settings.openai_timeout_seconds = 300
+    settings.bot_max_concurrency = 5
+
+    with (
+        patch("src.transform.llm_http.DefaultAsyncHttpxClient") as mock_client_class,
+        patch("src.transform.llm_http.httpx") as mock_httpx,
+    ):
+        client = build_http_client(settings)
+
+    assert client is mock_client_class.return_value
+    mock_httpx.Timeout.assert_called_once_with(300, connect=CONNECT_TIMEOUT_SECONDS)
+    mock_httpx.Limits.assert_called_once_with(max_connections=5, max_keepalive_connections=5, keepalive_expiry=KEEPALIVE_EXPIRY_SECONDS)
+    mock_client_class.assert_called_once_with(timeout=mock_httpx.Timeout.return_value, limits=mock_httpx.Limits.return_value)
@@ -0,0 +1,48 @@
+from unittest.mock import MagicMock
+
+import pytest
+from src.cache import InMemoryCacheProvider
+from src.config import Settings
+from src.transform.model_selection import ModelSelection
+
+
+def build_model_selection(openai_models: tuple[str, ...] = ("gpt-4o", "gpt-4o-mini")) -> ModelSelection:
+    settings = MagicMock(spec=Settings)
+    settings.openai_model = "gpt-4o-mini"
+    settings.openai_models = openai_models
+    return ModelSelection(InMemoryCacheProvider(), settings)
+
+
+def test_allowed_models_start_with_default_model() -> None:
+    assert build_model_selection().allowed_models() == ("gpt-4o-mini", "gpt-4o")
+    assert build_model_selection(()).allowed_models() == ("gpt-4o-mini",)
+
+
+@pytest.mark.asyncio
+async def test_model_selection_set_and_get() -> None:
+    model_selection = build_model_selection()
+    assert await model_selection.get() == "gpt-4o-mini"
+
+    await model_selection.set("gpt-4o")
+
+    assert await model_selection.get() == "gpt-4o"
+
+
+@pytest.mark.asyncio
+async def test_model_selection_rejects_model_not_allowed() -> None:
+    model_selection = build_model_selection()
+
+    with pytest.raises(ValueError, match="not allowed"):
+        await model_selection.set("gpt-5")
+
+    assert await model_selection.get() == "gpt-4o-mini"
+
+
+@pytest.mark.asyncio
+async def test_model_selection_falls_back_when_model_is_no_longer_allowed() -> None:
+    model_selection = build_model_selection()
+    await model_selection.set("gpt-4o")
+
+    model_selection.settings.openai_models = ()
+
+    assert await model_selection.get() == "gpt-4o-mini"
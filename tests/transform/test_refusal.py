@@ -0,0 +1,30 @@
+import pytest
+from src.transform.refusal import MAX_REFUSAL_LENGTH, is_refusal
+
+
+@pytest.mark.parametrize(
+    "text",
+    [
+        "I cannot summarize this content.",
+        "I'm sorry, but I can't help with that.",
+        "Sorry, I am unable to provide a summary of this transcript.",
+        "Unfortunately, I can’t assist with this request.",
+        "As an AI language model, I cannot summarize copyrighted material.",
+        "I won't summarize this.",
+    ],
+)
+def test_is_refusal_detects_refusals(text: str) -> None:
+    assert is_refusal(text)
+
+
+@pytest.mark.parametrize(
+    "text",
+    [
+        "The speaker explains why they cannot help their neighbor.",
+        "I can't stress enough how useful this tool is, says the author.",
+        "- Point one\n- Point two",
+        "I cannot summarize this. " + "x" * MAX_REFUSAL_LENGTH,
+    ],
+)
+def test_is_refusal_ignores_summaries(text: str) -> None:
+    assert not is_refusal(text)
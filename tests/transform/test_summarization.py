@@ -1,8 +1,12 @@
-from unittest.mock import AsyncMock, MagicMock, patch
+import asyncio
+from unittest.mock import ANY, AsyncMock, MagicMock, patch
 
 import pytest
-from src.config import Settings
-from src.transform.summarization import OpenAISummarizer
+from openai import APITimeoutError
+from src.config import FeatureFlags, Settings
+from src.transform.generation_params import GenerationParams
+from src.transform.summarization import EmptySummaryError, OpenAISummarizer, SummarizationTimeoutError, SummaryRefusedError
+from src.transform.summary_style import SummaryStyle, style_instruction
 
 
 def build_settings(**overrides: object) -> Settings:
@@ -12,9 +16,17 @@ def build_settings(**overrides: object) -> Settings:
     settings.openai_model = "gpt-3.5-turbo"
     settings.openai_timeout_seconds = 300
     settings.openai_max_retries = 3
+    settings.bot_max_concurrency = 5
+    settings.openai_temperature = None
+    settings.openai_max_tokens = None
+    settings.summary_style = "default"
+    settings.summary_styles_by_language = {}
     settings.cache_summary_ttl_seconds = 3600
     settings.valkey_url = None
     settings.cache_compression_method = "gzip"
+    settings.feature_flags = FeatureFlags()
+    settings.max_transcript_chars = 0
+    settings.transcript_limit_policy = "truncate"
     for key, value in overrides.items():
         setattr(settings, key, value)
     return settings
@@ -24,21 +36,48 @@ def build_settings(**overrides: object) -> Settings:
 async def test_openai_summarizer_initialization() -> None:
     mock_settings = build_settings()
 
-    with patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class:
+    with (
+        patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class,
+        patch("src.transform.summarization.build_http_client") as mock_build_http_client,
+    ):
         mock_client_instance = MagicMock()
         mock_openai_class.return_value = mock_client_instance
 
         summarizer = OpenAISummarizer(mock_settings)
 
         # Verify the client was initialized with correct settings
+        mock_build_http_client.assert_called_once_with(mock_settings)
         mock_openai_class.assert_called_once_with(
             base_url="https://api.openai.com/v1/",
             api_key="test-key",
             max_retries=3,
+            http_client=mock_build_http_client.return_value,
         )
         assert summarizer.settings == mock_settings
 
 
+@pytest.mark.asyncio
+async def test_summarizer_reuses_client_across_requests() -> None:
+    with (
+        patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class,
+        patch("src.transform.summarization.build_http_client") as mock_build_http_client,
+    ):
+        mock_choice = MagicMock()
+        mock_choice.message.content = "This is the summary"
+        mock_response = MagicMock(choices=[mock_choice])
+        mock_openai_class.return_value.chat.completions.create = AsyncMock(return_value=mock_response)
+
+        summarizer = OpenAISummarizer(build_settings())
+        summarizer.cache_provider = AsyncMock()
+        summarizer.cache_provider.get.return_value = None
+        await summarizer.summarize("First text", "en")
+        await summarizer.summarize("Second text", "en")
+
+    mock_build_http_client.assert_called_once()
+    mock_openai_class.assert_called_once()
+    assert mock_openai_class.return_value.chat.completions.create.await_count == 2
+
+
 @pytest.mark.asyncio
 async def test_summarizer_summarize_text_success() -> None:
     mock_settings = build_settings()
@@ -55,7 +94,7 @@ async def test_summarizer_summarize_text_success() -> None:
         mock_openai_class.return_value = mock_client_instance
 
         # Mock the translate function to return a fixed system prompt
-        with patch("src.transform.summarization.translate") as mock_translate:
+        with patch("src.transform.summary_prompt.translate") as mock_translate:
             mock_translate.return_value = "Input text to summarize"
 
             summarizer = OpenAISummarizer(mock_settings)
@@ -73,6 +112,36 @@ async def test_summarizer_summarize_text_success() -> None:
             assert result == "This is the summary"
 
 
+@pytest.mark.asyncio
+async def test_summarizer_records_token_usage() -> None:
+    mock_settings = build_settings()
+
+    with patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class:
+        mock_client_instance = MagicMock()
+        mock_response = MagicMock()
+        mock_choice = MagicMock()
+        mock_choice.message.content = "This is the summary"
+        mock_response.choices = [mock_choice]
+        mock_response.model = "gpt-3.5-turbo-0125"
+        mock_response.usage.prompt_tokens = 120
+        mock_response.usage.completion_tokens = 30
+        mock_response.usage.total_tokens = 150
+        mock_client_instance.chat.completions.create = AsyncMock(return_value=mock_response)
+        mock_openai_class.return_value = mock_client_instance
+
+        with patch("src.transform.summary_prompt.translate", return_value="Input text to summarize"):
+            summarizer = OpenAISummarizer(mock_settings)
+            await summarizer._summarize("Input text to summarize", "en")
+            await summarizer._summarize("Input text to summarize", "en")
+
+        totals = summarizer.get_usage_totals()
+        expected_requests = 2
+        assert totals.requests == expected_requests
+        assert totals.prompt_tokens == 120 * expected_requests
+        assert totals.completion_tokens == 30 * expected_requests
+        assert totals.total_tokens == 150 * expected_requests
+
+
 @pytest.mark.asyncio
 async def test_summarizer_summarize_text_empty_response() -> None:
     mock_settings = build_settings()
@@ -95,7 +164,7 @@ async def test_summarizer_summarize_text_empty_response() -> None:
         mock_openai_class.return_value = mock_client_instance
 
         # Mock the translate function
-        with patch("src.transform.summarization.translate") as mock_translate:
+        with patch("src.transform.summary_prompt.translate") as mock_translate:
             mock_translate.return_value = "You are a helpful assistant."
 
             summarizer = OpenAISummarizer(mock_settings)
@@ -122,7 +191,7 @@ async def test_summarizer_summarize_text_with_retry_failure() -> None:
         mock_openai_class.return_value = mock_client_instance
 
         # Mock the translate function
-        with patch("src.transform.summarization.translate") as mock_translate:
+        with patch("src.transform.summary_prompt.translate") as mock_translate:
             mock_translate.return_value = "You are a helpful assistant."
 
             summarizer = OpenAISummarizer(mock_settings)
@@ -134,6 +203,41 @@ async def test_summarizer_summarize_text_with_retry_failure() -> None:
             assert mock_client_instance.chat.completions.create.call_count == expected_calls
 
 
+@pytest.mark.asyncio
+async def test_summarizer_raises_timeout_error() -> None:
+    mock_settings = build_settings(openai_timeout_seconds=5)
+
+    with patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class:
+        mock_client_instance = MagicMock()
+        mock_client_instance.chat.completions.create = AsyncMock(side_effect=APITimeoutError(request=MagicMock()))
+        mock_openai_class.return_value = mock_client_instance
+
+        with patch("src.transform.summary_prompt.translate", return_value="Input text to summarize"):
+            summarizer = OpenAISummarizer(mock_settings)
+
+            with pytest.raises(SummarizationTimeoutError, match="timed out after 5s"):
+                await summarizer._summarize("Input text to summarize", "en")
+
+        assert mock_client_instance.chat.completions.create.call_args.kwargs["timeout"] == mock_settings.openai_timeout_seconds
+
+
+@pytest.mark.asyncio
+async def test_summarizer_propagates_cancellation() -> None:
+    mock_settings = build_settings()
+
+    with patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class:
+        mock_client_instance = MagicMock()
+        mock_client_instance.chat.completions.create = AsyncMock(side_effect=asyncio.CancelledError())
+        mock_openai_class.return_value = mock_client_instance
+
+        with patch("src.transform.summary_prompt.translate", return_value="Input text to summarize"):
+            summarizer = OpenAISummarizer(mock_settings)
+
+            # Cancellation must not be swallowed or wrapped into a summarization failure
+            with pytest.raises(asyncio.CancelledError):
+                await summarizer._summarize("Input text to summarize", "en")
+
+
 @pytest.mark.asyncio
 async def test_summarizer_invalid_args() -> None:
     mock_settings = build_settings()
@@ -177,7 +281,304 @@ async def test_summarize_uncached() -> None:
         assert result == "New summary"
         mock_provider.get.assert_called_once()
         mock_provider.put.assert_called_once_with(
-            "summary::75b697462588792e2fc85fa00b5dc51992b25be2d780349f0827fea9311aea8b:en",
+            "summary::75b697462588792e2fc85fa00b5dc51992b25be2d780349f0827fea9311aea8b:gpt-3.5-turbo:p1:en",
             "New summary",
             mock_settings.cache_summary_ttl_seconds,
         )
+
+
+@pytest.mark.asyncio
+async def test_summarize_refresh_replaces_cached_summary() -> None:
+    summarizer = OpenAISummarizer(build_settings())
+    summarizer.cache_provider = AsyncMock()
+    summarizer.cache_provider.get.return_value = "Cached summary"
+
+    with patch.object(summarizer, "_summarize", return_value="New summary"):
+        result = await summarizer.summarize("Input text", "en", refresh=True)
+
+    assert result == "New summary"
+    summarizer.cache_provider.get.assert_not_called()
+    summarizer.cache_provider.put.assert_called_once_with(ANY, "New summary", 3600)
+
+
+@pytest.mark.asyncio
+async def test_summarize_limits_long_transcript() -> None:
+    max_chars = 100
+    summarizer = OpenAISummarizer(build_settings(max_transcript_chars=max_chars, transcript_limit_policy="sample"))
+    summarizer.cache_provider = AsyncMock()
+    summarizer.cache_provider.get.return_value = None
+
+    with (
+        patch.object(summarizer, "_summarize", return_value="Summary") as mock_summarize,
+        patch("src.transform.summary_prompt.translate", side_effect=lambda key, **kw: key),
+    ):
+        result = await summarizer.summarize("word " * 100, "en")
+
+    assert len(mock_summarize.call_args.args[0]) <= max_chars
+    assert result == "Summary\n\nopenai.limit_note.sample"
+    summarizer.cache_provider.put.assert_called_once_with(ANY, "Summary", 3600)
+
+
+@pytest.mark.asyncio
+async def test_summarizer_adds_style_instruction() -> None:
+    mock_settings = build_settings()
+
+    with patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class:
+        mock_client_instance = MagicMock()
+        mock_response = MagicMock()
+        mock_choice = MagicMock()
+        mock_choice.message.content = "- Point"
+        mock_response.choices = [mock_choice]
+        mock_client_instance.chat.completions.create = AsyncMock(return_value=mock_response)
+        mock_openai_class.return_value = mock_client_instance
+
+        with patch("src.transform.summary_prompt.translate", return_value="Input text to summarize"):
+            summarizer = OpenAISummarizer(mock_settings)
+            result = await summarizer._summarize("Input text to summarize", "en", SummaryStyle.BULLETS)
+
+        messages = mock_client_instance.chat.completions.create.call_args.kwargs["messages"]
+        assert messages == [
+            {"role": "system", "content": style_instruction(SummaryStyle.BULLETS)},
+            {"role": "user", "content": "Input text to summarize"},
+        ]
+        assert result == "- Point"
+
+
+def test_style_instruction_mapping() -> None:
+    assert style_instruction(SummaryStyle.DEFAULT) is None
+    for style in (SummaryStyle.SHORT, SummaryStyle.DETAILED, SummaryStyle.BULLETS):
+        assert style_instruction(style)
+
+
+@pytest.mark.asyncio
+async def test_summarize_cache_key_includes_style() -> None:
+    mock_settings = build_settings()
+    summarizer = OpenAISummarizer(mock_settings)
+
+    mock_provider = AsyncMock()
+    mock_provider.get.return_value = None
+    summarizer.cache_provider = mock_provider
+
+    with patch.object(summarizer, "_summarize", return_value="Short summary") as mock_summarize:
+        await summarizer.summarize("Input text", "en", SummaryStyle.SHORT)
+
+    mock_summarize.assert_called_once_with("Input text", "en", SummaryStyle.SHORT, "gpt-3.5-turbo", GenerationParams())
+    cache_key = mock_provider.put.call_args.args[0]
+    assert cache_key.endswith(":en:short")
+
+
+@pytest.mark.asyncio
+async def test_summarize_keeps_summaries_per_model() -> None:
+    summarizer = OpenAISummarizer(build_settings())
+    cache: dict[str, str] = {}
+    summarizer.cache_provider = AsyncMock()
+    summarizer.cache_provider.get.side_effect = cache.get
+    summarizer.cache_provider.put.side_effect = lambda key, text, ttl: cache.__setitem__(key, text)
+
+    with patch.object(summarizer, "_summarize", side_effect=["Default model summary", "Other model summary"]) as mock_summarize:
+        assert await summarizer.summarize("Input text", "en") == "Default model summary"
+        assert await summarizer.summarize("Input text", "en", model="gpt-4o") == "Other model summary"
+        # Both summaries coexist and each model gets its own back
+        assert await summarizer.summarize("Input text", "en") == "Default model summary"
+        assert await summarizer.summarize("Input text", "en", model="gpt-4o") == "Other model summary"
+
+    expected_llm_calls = 2
+    assert mock_summarize.call_count == expected_llm_calls
+    assert mock_summarize.call_args_list[1].args == ("Input text", "en", SummaryStyle.DEFAULT, "gpt-4o", GenerationParams())
+
+
+@pytest.mark.asyncio
+async def test_summarize_cache_key_includes_prompt_version() -> None:
+    summarizer = OpenAISummarizer(build_settings())
+    summarizer.cache_provider = AsyncMock()
+    summarizer.cache_provider.get.return_value = None
+
+    with patch.object(summarizer, "_summarize", return_value="Summary"):
+        await summarizer.summarize("Input text", "en")
+        with patch("src.transform.summarization.prompt_version", 2):
+            await summarizer.summarize("Input text", "en")
+
+    first_key, second_key = (call.args[0] for call in summarizer.cache_provider.put.call_args_list)
+    assert ":p1:" in first_key
+    assert ":p2:" in second_key
+
+
+@pytest.mark.asyncio
+async def test_summarizer_uses_requested_model() -> None:
+    with patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class:
+        mock_client_instance = MagicMock()
+        mock_response = MagicMock()
+        mock_response.choices = [MagicMock()]
+        mock_response.choices[0].message.content = "Summary"
+        mock_client_instance.chat.completions.create = AsyncMock(return_value=mock_response)
+        mock_openai_class.return_value = mock_client_instance
+
+        with patch("src.transform.summary_prompt.translate", return_value="prompt"):
+            summarizer = OpenAISummarizer(build_settings())
+            await summarizer._summarize("Input text", "en", model="gpt-4o")
+
+    assert mock_client_instance.chat.completions.create.call_args.kwargs["model"] == "gpt-4o"
+
+
+@pytest.mark.parametrize(
+    ("summary_cleanup", "expected"),
+    [
+        (True, "- Point"),
+        (False, "Here is a summary of the video:\n- Point"),
+    ],
+)
+@pytest.mark.asyncio
+async def test_summarizer_cleans_response(summary_cleanup: bool, expected: str) -> None:
+    with patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class:
+        mock_client_instance = MagicMock()
+        mock_response = MagicMock()
+        mock_response.choices = [MagicMock()]
+        mock_response.choices[0].message.content = "Here is a summary of the video:\n- Point"
+        mock_client_instance.chat.completions.create = AsyncMock(return_value=mock_response)
+        mock_openai_class.return_value = mock_client_instance
+
+        with patch("src.transform.summary_prompt.translate", return_value="prompt"):
+            summarizer = OpenAISummarizer(build_settings(feature_flags=FeatureFlags(summary_cleanup=summary_cleanup)))
+            result = await summarizer._summarize("Input text", "en")
+
+    assert result == expected
+
+
+@pytest.mark.parametrize(
+    ("content", "expected_error"),
+    [
+        ("  \n\t ", EmptySummaryError),
+        ("I'm sorry, but I can't summarize this content.", SummaryRefusedError),
+        ("Here is a summary:\nI cannot help with that request.", SummaryRefusedError),
+    ],
+)
+@pytest.mark.asyncio
+async def test_summarizer_rejects_unusable_content(content: str, expected_error: type[Exception]) -> None:
+    with patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class:
+        mock_client_instance = MagicMock()
+        mock_response = MagicMock()
+        mock_response.choices = [MagicMock()]
+        mock_response.choices[0].message.content = content
+        mock_client_instance.chat.completions.create = AsyncMock(return_value=mock_response)
+        mock_openai_class.return_value = mock_client_instance
+
+        with patch("src.transform.summary_prompt.translate", return_value="prompt"):
+            summarizer = OpenAISummarizer(build_settings())
+            summarizer.cache_provider = AsyncMock()
+            summarizer.cache_provider.get.return_value = None
+            with pytest.raises(expected_error):
+                await summarizer.summarize("Input text", "en")
+
+    summarizer.cache_provider.put.assert_not_called()
+
+
+@pytest.mark.parametrize(
+    ("configured", "overrides", "expected_kwargs"),
+    [
+        ({}, None, {}),
+        ({"openai_temperature": 0.3, "openai_max_tokens": 512}, None, {"temperature": 0.3, "max_tokens": 512}),
+        ({"openai_temperature": 0.3, "openai_max_tokens": 512}, GenerationParams(temperature=1.2), {"temperature": 1.2, "max_tokens": 512}),
+        ({}, GenerationParams(max_tokens=256), {"max_tokens": 256}),
+    ],
+)
+@pytest.mark.asyncio
+async def test_summarizer_sends_generation_params(
+    configured: dict[str, object], overrides: GenerationParams | None, expected_kwargs: dict[str, object]
+) -> None:
+    with patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class:
+        mock_response = MagicMock()
+        mock_response.choices = [MagicMock()]
+        mock_response.choices[0].message.content = "Summary"
+        mock_create = AsyncMock(return_value=mock_response)
+        mock_openai_class.return_value.chat.completions.create = mock_create
+
+        with patch("src.transform.summary_prompt.translate", return_value="prompt"):
+            summarizer = OpenAISummarizer(build_settings(**configured))
+            summarizer.cache_provider = AsyncMock()
+            summarizer.cache_provider.get.return_value = None
+            await summarizer.summarize("Input text", "en", params=overrides)
+
+    kwargs = mock_create.call_args.kwargs
+    assert {key: kwargs[key] for key in ("temperature", "max_tokens") if key in kwargs} == expected_kwargs
+
+
+@pytest.mark.asyncio
+async def test_summarizer_caches_per_generation_params() -> None:
+    with patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class:
+        mock_response = MagicMock()
+        mock_response.choices = [MagicMock()]
+        mock_response.choices[0].message.content = "Summary"
+        mock_openai_class.return_value.chat.completions.create = AsyncMock(return_value=mock_response)
+
+        with patch("src.transform.summary_prompt.translate", return_value="prompt"):
+            summarizer = OpenAISummarizer(build_settings())
+            summarizer.cache_provider = AsyncMock()
+            summarizer.cache_provider.get.return_value = None
+            await summarizer.summarize("Input text", "en")
+            await summarizer.summarize("Input text", "en", params=GenerationParams(temperature=0.5, max_tokens=100))
+
+    default_key, tuned_key = (call.args[0] for call in summarizer.cache_provider.put.call_args_list)
+    assert tuned_key == f"{default_key}:t0.5:m100"
+
+
+@pytest.mark.asyncio
+async def test_summarize_uses_active_model() -> None:
+    model_selection = AsyncMock()
+    model_selection.get.return_value = "gpt-4o"
+    summarizer = OpenAISummarizer(build_settings(), model_selection)
+    summarizer.cache_provider = AsyncMock()
+    summarizer.cache_provider.get.return_value = None
+
+    with patch.object(summarizer, "_summarize", return_value="Summary") as mock_summarize:
+        await summarizer.summarize("Input text", "en")
+
+    assert mock_summarize.call_args.args[3] == "gpt-4o"
+    assert ":gpt-4o:" in summarizer.cache_provider.put.call_args.args[0]
+
+
+@pytest.mark.asyncio
+async def test_summarizers_with_different_settings_are_independent() -> None:
+    with patch("src.transform.summarization.AsyncOpenAI") as mock_openai_class:
+        mock_openai_class.side_effect = lambda **kwargs: MagicMock()
+
+        with patch("src.transform.summary_prompt.translate", return_value="prompt"):
+            summarizers = [OpenAISummarizer(build_settings(openai_model=model)) for model in ("gpt-4o-mini", "gpt-4o")]
+            for summarizer in summarizers:
+                mock_response = MagicMock()
+                mock_response.choices = [MagicMock()]
+                mock_response.choices[0].message.content = "Summary"
+                summarizer.client.chat.completions.create = AsyncMock(return_value=mock_response)
+                await summarizer._summarize("Input text", "en")
+
+    assert summarizers[0].client is not summarizers[1].client
+    assert [summarizer.client.chat.completions.create.call_args.kwargs["model"] for summarizer in summarizers] == ["gpt-4o-mini", "gpt-4o"]
+
+
+@pytest.mark.parametrize(
+    ("locale", "expected_style"),
+    [
+        ("ja", SummaryStyle.DETAILED),  # language with an override
+        ("ja-JP", SummaryStyle.DETAILED),
+        ("en", SummaryStyle.BULLETS),  # falls back to the global default
+    ],
+)
+def test_summarizer_default_style_per_language(locale: str, expected_style: SummaryStyle) -> None:
+    with patch("src.transform.summarization.AsyncOpenAI"):
+        summarizer = OpenAISummarizer(build_settings(summary_style="bullets", summary_styles_by_language={"ja": "detailed"}))
+
+    assert summarizer.default_style(locale) is expected_style
+
+
+@pytest.mark.asyncio
+async def test_summarize_uses_language_style_unless_requested() -> None:
+    with patch("src.transform.summarization.AsyncOpenAI"):
+        summarizer = OpenAISummarizer(build_settings(summary_styles_by_language={"ja": "short"}))
+    summarizer.cache_provider = AsyncMock()
+    summarizer.cache_provider.get.return_value = None
+
+    with patch.object(summarizer, "_summarize", AsyncMock(return_value="Summary")) as mock_summarize:
+        await summarizer.summarize("Input text", "ja")
+        await summarizer.summarize("Input text", "ja", SummaryStyle.DEFAULT)
+        await summarizer.summarize("Input text", "en")
+
+    assert [call.args[2] for call in mock_summarize.call_args_list] == [SummaryStyle.SHORT, SummaryStyle.DEFAULT, SummaryStyle.DEFAULT]
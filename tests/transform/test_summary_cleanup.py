@@ -0,0 +1,49 @@
+import pytest
+from src.transform.summary_cleanup import clean_summary, strip_preamble, strip_summary_heading, unwrap_code_fence
+
+
+@pytest.mark.parametrize(
+    ("raw", "expected"),
+    [
+        ("The video explains X.", "The video explains X."),
+        ("  The video explains X.\n\n", "The video explains X."),
+        ("Here is a summary of the video:\n\nThe video explains X.", "The video explains X."),
+        ("Sure! Here's a concise summary:\n- Point one\n- Point two", "- Point one\n- Point two"),
+        ("Certainly, here are the key points:\n\n1. First", "1. First"),
+        ("```markdown\n## Main idea\nThe video explains X.\n```", "## Main idea\nThe video explains X."),
+        ("```\nThe video explains X.\n```\n", "The video explains X."),
+        ("Here is the summary:\n```md\n- Point\n```", "- Point"),
+        ("# Summary\n\nThe video explains X.", "The video explains X."),
+        ("**Summary:**\nThe video explains X.", "The video explains X."),
+        ("Here is a summary:\n```\n**Summary**\n- Point\n```", "- Point"),
+    ],
+)
+def test_clean_summary(raw: str, expected: str) -> None:
+    assert clean_summary(raw) == expected
+
+
+@pytest.mark.parametrize(
+    "text",
+    [
+        "Here is why the author disagrees: the data is old.",
+        "Summary of findings: growth slowed in 2024.",
+        "Use ```code``` blocks like this.\n```\nprint(1)\n```",
+        "## Summary of the debate\nBoth sides agree.",
+    ],
+)
+def test_clean_summary_keeps_content(text: str) -> None:
+    assert clean_summary(text) == text
+
+
+def test_clean_summary_keeps_original_if_nothing_left() -> None:
+    assert clean_summary("Here is a summary:") == "Here is a summary:"
+
+
+def test_clean_summary_with_custom_rules() -> None:
+    assert clean_summary("```\nText\n```", rules=(unwrap_code_fence, str.upper)) == "TEXT"
+
+
+def test_rules_are_independent() -> None:
+    assert strip_preamble("Here's an overview:\nText") == "Text"
+    assert unwrap_code_fence("Text") == "Text"
+    assert strip_summary_heading("Summary\nText") == "Text"
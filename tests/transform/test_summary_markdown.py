@@ -0,0 +1,51 @@
+import pytest
+from src.load.video_loader import VideoTranscript
+from src.transform.summary_markdown import escape_markdown, escape_url, render_summary_markdown
+
+VIDEO_URL = "https://www.youtube.com/watch?v=dQw4w9WgXcQ"
+
+
+THUMBNAIL_URL = "https://i.ytimg.com/vi/dQw4w9WgXcQ/hq.jpg"
+
+
+def build_transcript(title: str = "Test Video", uploader: str = "Test Channel", thumbnail: str = THUMBNAIL_URL) -> VideoTranscript:
+    return VideoTranscript(id="dQw4w9WgXcQ", language="en", uploader=uploader, title=title, thumbnail=thumbnail, transcript="Transcript")
+
+
+def test_render_summary_markdown_full() -> None:
+    rendered = render_summary_markdown(VIDEO_URL, build_transcript(), "\n- Point one\n- Point two\n")
+
+    assert rendered == (
+        "# [Test Video](https://www.youtube.com/watch?v=dQw4w9WgXcQ)\n\n"
+        "*Test Channel*\n\n"
+        "![Test Video](https://i.ytimg.com/vi/dQw4w9WgXcQ/hq.jpg)\n\n"
+        "- Point one\n- Point two\n"
+    )
+
+
+def test_render_summary_markdown_without_uploader_and_thumbnail() -> None:
+    rendered = render_summary_markdown(VIDEO_URL, build_transcript(uploader=" ", thumbnail=""), "Summary")
+
+    assert rendered == "# [Test Video](https://www.youtube.com/watch?v=dQw4w9WgXcQ)\n\nSummary\n"
+
+
+def test_render_summary_markdown_without_title() -> None:
+    rendered = render_summary_markdown(VIDEO_URL, build_transcript(title="", uploader="", thumbnail=""), "Summary")
+
+    assert rendered.startswith("# [Video](")
+
+
+@pytest.mark.parametrize(
+    ("text", "expected"),
+    [
+        ("Plain title", "Plain title"),
+        ("[Live] my_video *final*", "\\[Live\\] my\\_video \\*final\\*"),
+        ("C:\\path `code`", "C:\\\\path \\`code\\`"),
+    ],
+)
+def test_escape_markdown(text: str, expected: str) -> None:
+    assert escape_markdown(text) == expected
+
+
+def test_escape_url() -> None:
+    assert escape_url(" https://example.com/a (b).jpg ") == "https://example.com/a%20%28b%29.jpg"
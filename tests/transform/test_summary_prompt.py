@@ -0,0 +1,28 @@
+from unittest.mock import patch
+
+import pytest
+from src.transform.summary_prompt import build_prompt, with_limit_note
+from src.transform.transcript_limit import TranscriptLimitPolicy
+
+
+def test_build_prompt_uses_translated_prompt() -> None:
+    with patch("src.transform.summary_prompt.translate", return_value="Prompt") as mock_translate:
+        assert build_prompt("Input text", "de") == "Prompt"
+
+    mock_translate.assert_called_once_with("openai.prompt", locale="de", text="Input text")
+
+
+def test_build_prompt_falls_back_to_english_with_target_language() -> None:
+    with patch("src.transform.summary_prompt.translate", return_value="Prompt") as mock_translate:
+        assert build_prompt("Input text", "pl") == "Prompt"
+
+    mock_translate.assert_called_once_with("openai.prompt_for_language", locale="en", text="Input text", language="pl")
+
+
+@pytest.mark.parametrize(
+    ("policy", "expected"),
+    [(None, "Summary"), (TranscriptLimitPolicy.TRUNCATE, "Summary\n\nopenai.limit_note.truncate")],
+)
+def test_with_limit_note(policy: TranscriptLimitPolicy | None, expected: str) -> None:
+    with patch("src.transform.summary_prompt.translate", side_effect=lambda key, **kw: key):
+        assert with_limit_note("Summary", policy, "en") == expected
@@ -0,0 +1,53 @@
+import sys
+from collections.abc import Iterator
+from unittest.mock import MagicMock, patch
+
+import pytest
+from src.transform.token_estimate import CHARS_PER_TOKEN, _encoding_for_model, estimate_tokens
+
+
+@pytest.fixture(autouse=True)
+def clear_encoding_cache() -> Iterator[None]:
+    _encoding_for_model.cache_clear()
+    yield
+    _encoding_for_model.cache_clear()
+
+
+@pytest.mark.parametrize(
+    ("text", "model", "expected"),
+    [
+        ("hello world", "gpt-4", 2),
+        ("tiktoken is great!", "gpt-3.5-turbo", 6),
+    ],
+)
+def test_estimate_tokens_counts_known_models_exactly(text: str, model: str, expected: int) -> None:
+    pytest.importorskip("tiktoken")
+
+    assert estimate_tokens(text, model) == expected
+
+
+def test_estimate_tokens_falls_back_to_length_for_unknown_model() -> None:
+    text = "a" * (CHARS_PER_TOKEN * 10 + 1)
+
+    assert estimate_tokens(text, "llama-3.1-8b-instruct") == 11
+
+
+def test_estimate_tokens_falls_back_to_length_without_tiktoken() -> None:
+    with patch.dict(sys.modules, {"tiktoken": None}):
+        assert estimate_tokens("hello world", "gpt-4") == 3
+
+
+def test_estimate_tokens_falls_back_to_length_if_encoding_cannot_be_loaded() -> None:
+    tiktoken = MagicMock()
+    tiktoken.encoding_for_model.side_effect = OSError("Could not download cl100k_base.tiktoken")
+
+    with patch.dict(sys.modules, {"tiktoken": tiktoken}):
+        assert estimate_tokens("hello world", "gpt-4") == 3
+        assert estimate_tokens("hello world", "gpt-4") == 3
+
+    # The failure is cached rather than retried on every request
+    tiktoken.encoding_for_model.assert_called_once_with("gpt-4")
+
+
+def test_estimate_tokens_of_empty_text() -> None:
+    assert estimate_tokens("", "gpt-4") == 0
@@ -0,0 +1,48 @@
+import pytest
+from src.transform.transcript_limit import OMISSION_MARKER, SAMPLE_EXCERPTS, LimitedTranscript, TranscriptLimitPolicy, limit_transcript
+
+TRANSCRIPT = " ".join(f"w{index:03}" for index in range(400))
+
+
+@pytest.mark.parametrize("policy", list(TranscriptLimitPolicy))
+def test_limit_transcript_passes_through_short_text(policy: TranscriptLimitPolicy) -> None:
+    assert limit_transcript("short text", 100, policy) == LimitedTranscript("short text")
+
+
+def test_limit_transcript_disabled() -> None:
+    assert limit_transcript(TRANSCRIPT, 0, TranscriptLimitPolicy.TRUNCATE) == LimitedTranscript(TRANSCRIPT)
+
+
+def test_limit_transcript_truncates() -> None:
+    max_chars = 100
+    limited = limit_transcript(TRANSCRIPT, max_chars, TranscriptLimitPolicy.TRUNCATE)
+
+    assert limited.applied_policy is TranscriptLimitPolicy.TRUNCATE
+    assert len(limited.text) <= max_chars
+    assert limited.text.startswith("w000 w001")
+    assert limited.text.endswith(OMISSION_MARKER.rstrip())
+    # The cut falls between words
+    assert limited.text.removesuffix(OMISSION_MARKER.rstrip()).split()[-1].startswith("w")
+    assert all(len(word) == len("w000") for word in limited.text.split()[:-1])
+
+
+def test_limit_transcript_samples_across_whole_text() -> None:
+    max_chars = 400
+    limited = limit_transcript(TRANSCRIPT, max_chars, TranscriptLimitPolicy.SAMPLE)
+
+    assert limited.applied_policy is TranscriptLimitPolicy.SAMPLE
+    assert len(limited.text) <= max_chars
+    assert limited.text.count(OMISSION_MARKER) == SAMPLE_EXCERPTS - 1
+    assert limited.text.startswith("w000")
+    # The last excerpt comes from the end of the transcript, not right after the first one
+    last_excerpt = limited.text.split(OMISSION_MARKER)[-1]
+    expected_min_word = 300
+    assert int(last_excerpt.split()[1][1:]) >= expected_min_word
+
+
+def test_limit_transcript_samples_falls_back_to_truncate_for_tiny_limit() -> None:
+    max_chars = 40
+    limited = limit_transcript(TRANSCRIPT, max_chars, TranscriptLimitPolicy.SAMPLE)
+
+    assert len(limited.text) <= max_chars
+    assert limited.text.startswith("w000")
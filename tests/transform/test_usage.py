@@ -0,0 +1,43 @@
+from unittest.mock import MagicMock
+
+from src.transform.usage import LLMUsage, UsageTotals
+
+
+def test_llm_usage_from_completion() -> None:
+    response = MagicMock()
+    response.model = "gpt-4o-mini"
+    response.usage.prompt_tokens = 100
+    response.usage.completion_tokens = 20
+    response.usage.total_tokens = 120
+
+    usage = LLMUsage.from_completion(response, "fallback-model")
+
+    assert usage == LLMUsage(model="gpt-4o-mini", prompt_tokens=100, completion_tokens=20, total_tokens=120)
+
+
+def test_llm_usage_from_completion_without_usage() -> None:
+    response = MagicMock()
+    response.usage = None
+
+    assert LLMUsage.from_completion(response, "fallback-model") is None
+
+
+def test_llm_usage_falls_back_to_configured_model() -> None:
+    response = MagicMock()
+    response.model = None
+    response.usage.prompt_tokens = 10
+    response.usage.completion_tokens = 5
+    response.usage.total_tokens = None
+
+    usage = LLMUsage.from_completion(response, "fallback-model")
+
+    assert usage == LLMUsage(model="fallback-model", prompt_tokens=10, completion_tokens=5, total_tokens=15)
+
+
+def test_usage_totals_add() -> None:
+    totals = UsageTotals()
+
+    totals.add(LLMUsage(model="m", prompt_tokens=10, completion_tokens=5, total_tokens=15))
+    totals.add(LLMUsage(model="m", prompt_tokens=1, completion_tokens=2, total_tokens=3))
+
+    assert totals == UsageTotals(requests=2, prompt_tokens=11, completion_tokens=7, total_tokens=18)
@@ -1,4 +1,5 @@
-from src.utils.markdown import markdown_to_telegram_html
+import pytest
+from src.utils.markdown import markdown_to_telegram_html, telegram_html_to_text
 
 
 def test_markdown_to_telegram_html_with_empty_text() -> None:
@@ -167,3 +168,16 @@ def test_markdown_to_telegram_html_with_headers_collapses_newlines() -> None:
     result = markdown_to_telegram_html(md_text)
     # Should not have multiple consecutive newlines
     assert "\n\n\n" not in result
+
+
+@pytest.mark.parametrize(
+    ("html_text", "expected_text"),
+    [
+        ("<strong>Title</strong>\nTom &amp; Jerry &lt;3", "Title\nTom & Jerry <3"),
+        ('<a href="https://example.com">site</a>', "site (https://example.com)"),
+        ('<a href="https://example.com">https://example.com</a>', "https://example.com"),
+        ("<b>bold <i>unbalanced</b> tags", "bold unbalanced tags"),
+    ],
+)
+def test_telegram_html_to_text(html_text: str, expected_text: str) -> None:
+    assert telegram_html_to_text(html_text) == expected_text
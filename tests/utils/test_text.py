@@ -1,4 +1,5 @@
-from src.utils.text import to_lexical_chunks
+import pytest
+from src.utils.text import format_duration, to_lexical_chunks
 
 
 def test_to_lexical_chunks_handles_empty_text() -> None:
@@ -73,3 +74,17 @@ def test_to_lexical_chunks_keeps_word_boundaries_when_possible() -> None:
         "and should be",
         "split.",
     ]
+
+
+@pytest.mark.parametrize(
+    ("seconds", "expected"),
+    [
+        (None, "—"),
+        (59, "0:59"),
+        (754, "12:34"),
+        (754.9, "12:34"),
+        (3725, "1:02:05"),
+    ],
+)
+def test_format_duration(seconds: float | None, expected: str) -> None:
+    assert format_duration(seconds) == expected